@@ -5,9 +5,10 @@ go 1.22
 toolchain go1.23.2
 
 require (
-	github.com/aws/aws-sdk-go v1.43.41
+	github.com/aws/aws-sdk-go v1.43.43
 	github.com/aws/aws-sdk-go-v2/config v1.29.8
 	github.com/aws/aws-sdk-go-v2/credentials v1.17.61
+	github.com/aws/aws-sdk-go-v2/service/secretsmanager v1.35.2
 	github.com/aws/aws-sdk-go-v2/service/sesv2 v1.43.0
 	github.com/cenkalti/backoff/v4 v4.1.3
 	github.com/gofrs/uuid v4.2.0+incompatible
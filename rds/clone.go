@@ -0,0 +1,411 @@
+package rds
+
+import (
+	goctx "context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+const (
+	// CloneTagKey tag marking clusters created by CreateEphemeralClone, the only clusters teardown and the sweeper delete
+	CloneTagKey = "ephemeral-clone"
+	// CloneSourceTagKey tag holding the cluster a clone was restored from
+	CloneSourceTagKey = "ephemeral-clone-source"
+	// CloneExpiresAtTagKey tag holding the RFC 3339 time after which the sweeper deletes a clone
+	CloneExpiresAtTagKey = "ephemeral-clone-expires-at"
+)
+
+// DefaultCloneTTL lifetime of a clone when no TTL is configured
+const DefaultCloneTTL = 24 * time.Hour
+
+// ErrNotEphemeralClone teardown refused to delete a cluster not tagged as an ephemeral clone
+var ErrNotEphemeralClone = errors.New("rds: not an ephemeral clone")
+
+// CreateEphemeralCloneOpts create ephemeral clone options
+type CreateEphemeralCloneOpts struct {
+	// SourceDBClusterIdentifier cluster whose latest available automated snapshot is restored
+	SourceDBClusterIdentifier string
+	// NamePrefix clone identifier prefix, defaults to "<source>-clone"
+	NamePrefix string
+	// TTL lifetime of the clone, defaults to DefaultCloneTTL
+	TTL time.Duration
+	// Restore restore options of the clone, InstanceClass is required.
+	// DBClusterIdentifier and SnapshotIdentifier are ignored, Timeout is capped by the remaining workflow Timeout
+	Restore *RestoreDBClusterFromSnapshotOpts
+	// Prepare runs against the ready clone before it is handed out, for example to mask PII.
+	// The clone is torn down when it fails
+	Prepare func(ctx goctx.Context, clone *EphemeralClone) error
+	// Timeout of the whole workflow, defaults to 60 minutes
+	Timeout time.Duration
+}
+
+// CreateEphemeralCloneResponse create ephemeral clone response
+type CreateEphemeralCloneResponse struct {
+	Clone *EphemeralClone
+	Error error
+}
+
+// EphemeralClone handle of a cluster restored for temporary use
+type EphemeralClone struct {
+	DBClusterIdentifier   string
+	DBInstanceIdentifiers []string
+	SnapshotIdentifier    string
+	Endpoint              string
+	ReaderEndpoint        string
+	ExpiresAt             time.Time
+	service               *Service
+}
+
+// SweepEphemeralClonesOpts sweep expired ephemeral clones options
+type SweepEphemeralClonesOpts struct {
+	// DryRun lists the expired clones without deleting them
+	DryRun bool
+	// Wait polling of the deletions
+	Wait *WaitOpts
+	// Timeout of the whole sweep
+	Timeout time.Duration
+}
+
+// SweepEphemeralClonesResponse sweep expired ephemeral clones response
+type SweepEphemeralClonesResponse struct {
+	// Deleted clone identifiers deleted, or to be deleted on dry run
+	Deleted []string
+	// Error errors of every clone which could not be deleted, the others are still deleted
+	Error error
+}
+
+// CreateEphemeralClone restores the latest available automated snapshot of a cluster under a unique name tagged with an expiry,
+// waits until it is ready and runs Prepare. Tear it down with Teardown, or let SweepEphemeralClones delete it once expired
+func (s *Service) CreateEphemeralClone(opts *CreateEphemeralCloneOpts) (resp *CreateEphemeralCloneResponse) {
+	s.context.check()
+	resp = new(CreateEphemeralCloneResponse)
+
+	if opts.Restore == nil || opts.Restore.InstanceClass == "" {
+		resp.Error = fmt.Errorf("rds: ephemeral clone requires Restore.InstanceClass")
+		return
+	}
+
+	if opts.Restore.InstanceCount > 99 {
+		resp.Error = fmt.Errorf("rds: ephemeral clone supports at most 99 instances")
+		return
+	}
+
+	t := 60 * time.Minute
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	// every step runs within what is left of the workflow timeout
+	remaining, err := remainingTimeout(ctx)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	snapshot, err := s.DescribeLatestDBSnapshot(&DescribeDBClusterSnapshotsOpts{
+		DBClusterIdentifier: opts.SourceDBClusterIdentifier,
+		SnapshotType:        DBSnapshotsTypeAutomated,
+		Status:              "available",
+		Timeout:             remaining,
+	})
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	if snapshot == nil {
+		resp.Error = fmt.Errorf("rds: no available automated snapshot of %s", opts.SourceDBClusterIdentifier)
+		return
+	}
+
+	prefix := opts.NamePrefix
+	if prefix == "" {
+		prefix = opts.SourceDBClusterIdentifier + "-clone"
+	}
+
+	identifier, err := cloneIdentifier(prefix, time.Now())
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultCloneTTL
+	}
+
+	clone := &EphemeralClone{
+		DBClusterIdentifier: identifier,
+		SnapshotIdentifier:  aws.StringValue(snapshot.DBClusterSnapshotIdentifier),
+		ExpiresAt:           time.Now().Add(ttl).UTC().Truncate(time.Second),
+		service:             s,
+	}
+
+	restore := *opts.Restore
+	restore.DBClusterIdentifier = clone.DBClusterIdentifier
+	restore.SnapshotIdentifier = clone.SnapshotIdentifier
+	if remaining, err = remainingTimeout(ctx); err != nil {
+		resp.Error = err
+		return
+	}
+
+	if restore.Timeout <= 0 || restore.Timeout > remaining {
+		restore.Timeout = remaining
+	}
+	restore.Tag = map[string]string{}
+	for k, v := range opts.Restore.Tag {
+		restore.Tag[k] = v
+	}
+	restore.Tag[CloneTagKey] = "true"
+	restore.Tag[CloneSourceTagKey] = opts.SourceDBClusterIdentifier
+	restore.Tag[CloneExpiresAtTagKey] = clone.ExpiresAt.Format(time.RFC3339)
+
+	restored := s.RestoreDBClusterFromSnapshot(&restore)
+	clone.DBInstanceIdentifiers = restored.DBInstanceIdentifiers
+	clone.Endpoint = restored.Endpoint
+	clone.ReaderEndpoint = restored.ReaderEndpoint
+
+	err = restored.Error
+	if err == nil && opts.Prepare != nil {
+		err = opts.Prepare(ctx, clone)
+	}
+
+	if err != nil {
+		// nothing is left behind when the restore itself was refused
+		if !errors.Is(err, ErrProtected) {
+			teardownCtx, teardownCancel := goctx.WithTimeout(goctx.Background(), 30*time.Minute)
+			defer teardownCancel()
+			err = errors.Join(err, clone.Teardown(teardownCtx))
+		}
+
+		resp.Error = err
+		return
+	}
+
+	resp.Clone = clone
+	return
+}
+
+// Teardown deletes the instances and the cluster of the clone, without final snapshot, and waits until they are gone
+func (c *EphemeralClone) Teardown(ctx goctx.Context) error {
+	return c.service.deleteEphemeralClone(ctx, c.DBClusterIdentifier, nil)
+}
+
+// SweepEphemeralClones deletes every ephemeral clone past its expiry
+func (s *Service) SweepEphemeralClones(opts *SweepEphemeralClonesOpts) (resp *SweepEphemeralClonesResponse) {
+	s.context.check()
+	resp = new(SweepEphemeralClonesResponse)
+
+	t := 60 * time.Minute
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	var expired []string
+	now := time.Now()
+	err := s.client().DescribeDBClustersPagesWithContext(ctx, new(rds.DescribeDBClustersInput), func(page *rds.DescribeDBClustersOutput, _ bool) bool {
+		for _, cluster := range page.DBClusters {
+			if isExpiredClone(cluster.TagList, now) {
+				expired = append(expired, aws.StringValue(cluster.DBClusterIdentifier))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	var errs []error
+	for _, identifier := range expired {
+		if !opts.DryRun {
+			if err := s.deleteEphemeralClone(ctx, identifier, opts.Wait); err != nil {
+				errs = append(errs, fmt.Errorf("rds: sweep %s: %w", identifier, err))
+				continue
+			}
+		}
+
+		resp.Deleted = append(resp.Deleted, identifier)
+	}
+
+	resp.Error = errors.Join(errs...)
+	return
+}
+
+// remainingTimeout time left before the deadline of ctx, the context error once it has passed
+func remainingTimeout(ctx goctx.Context) (time.Duration, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, goctx.DeadlineExceeded
+	}
+
+	return remaining, nil
+}
+
+// deleteEphemeralClone deletes a cluster tagged as ephemeral clone and its instances, subject to the guard
+func (s *Service) deleteEphemeralClone(ctx goctx.Context, identifier string, wait *WaitOpts) error {
+	client := s.client()
+	output, err := client.DescribeDBClustersWithContext(ctx, &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(identifier),
+	})
+	if isNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(output.DBClusters) == 0 {
+		return nil
+	}
+
+	cluster := output.DBClusters[0]
+	if !hasTag(cluster.TagList, CloneTagKey, "true") {
+		return fmt.Errorf("%w: %s", ErrNotEphemeralClone, identifier)
+	}
+
+	// the clone tag stands in for the confirmation, the guard still refuses protected identifiers and tags
+	err = s.checkGuard(ctx, &guardTarget{
+		resourceType: GuardResourceDBCluster,
+		identifier:   identifier,
+		destructive:  true,
+		confirmation: identifier,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, member := range cluster.DBClusterMembers {
+		instance := aws.StringValue(member.DBInstanceIdentifier)
+		err := s.deleteWhenReady(ctx, instance, wait, s.dbInstanceStatus, func() error {
+			_, err := client.DeleteDBInstanceWithContext(ctx, &rds.DeleteDBInstanceInput{
+				DBInstanceIdentifier: aws.String(instance),
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, member := range cluster.DBClusterMembers {
+		if err := s.WaitDBInstanceDeleted(ctx, aws.StringValue(member.DBInstanceIdentifier), wait); err != nil {
+			return err
+		}
+	}
+
+	err = s.deleteWhenReady(ctx, identifier, wait, s.dbClusterStatus, func() error {
+		_, err := client.DeleteDBClusterWithContext(ctx, &rds.DeleteDBClusterInput{
+			DBClusterIdentifier: aws.String(identifier),
+			SkipFinalSnapshot:   aws.Bool(true),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.WaitDBClusterDeleted(ctx, identifier, wait)
+}
+
+// deleteWhenReady requests a deletion, retrying while the resource is busy, for example still being created
+func (s *Service) deleteWhenReady(ctx goctx.Context, identifier string, wait *WaitOpts, status func(goctx.Context, string) (string, error), del func() error) error {
+	return waitFor(ctx, identifier, wait, func(ctx goctx.Context) (string, bool, error) {
+		current, err := status(ctx, identifier)
+		if err != nil {
+			return "", false, err
+		}
+
+		if current == statusNotFound || current == "deleting" {
+			return current, true, nil
+		}
+
+		err = del()
+		if err == nil || isNotFound(err) {
+			return current, true, nil
+		}
+
+		if isInvalidState(err) {
+			return current, false, nil
+		}
+
+		return current, false, err
+	})
+}
+
+// isExpiredClone whether tags mark an ephemeral clone expired at now
+func isExpiredClone(tags []*rds.Tag, now time.Time) bool {
+	if !hasTag(tags, CloneTagKey, "true") {
+		return false
+	}
+
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == CloneExpiresAtTagKey {
+			expiresAt, err := time.Parse(time.RFC3339, aws.StringValue(tag.Value))
+			return err == nil && now.After(expiresAt)
+		}
+	}
+
+	return false
+}
+
+// hasTag whether tags contain key with value
+func hasTag(tags []*rds.Tag, key, value string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cloneInstanceSuffix longest instance suffix appended to the cluster identifier, see createDBInstanceInputs
+const cloneInstanceSuffix = "-99"
+
+// cloneIdentifier unique cluster identifier "<prefix>-<yyyymmddhhmmss>-<random>", short enough for its instance
+// identifiers "<cluster>-<n>" to stay within the 63 character limit
+func cloneIdentifier(prefix string, now time.Time) (string, error) {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	suffix := "-" + now.UTC().Format("20060102150405") + "-" + hex.EncodeToString(b)
+	prefix = strings.ToLower(prefix)
+	if max := 63 - len(cloneInstanceSuffix) - len(suffix); len(prefix) > max {
+		prefix = prefix[:max]
+	}
+
+	return strings.TrimRight(prefix, "-") + suffix, nil
+}
+
+// isInvalidState whether err reports a resource already being deleted or otherwise busy
+func isInvalidState(err error) bool {
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return false
+	}
+
+	return aerr.Code() == rds.ErrCodeInvalidDBInstanceStateFault || aerr.Code() == rds.ErrCodeInvalidDBClusterStateFault
+}
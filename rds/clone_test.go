@@ -0,0 +1,74 @@
+package rds
+
+import (
+	goctx "context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneIdentifier(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 34, 56, 0, time.UTC)
+	id, err := cloneIdentifier("woodstock-prod-clone", now)
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^woodstock-prod-clone-20240630123456-[0-9a-f]{6}$`), id)
+
+	other, err := cloneIdentifier("woodstock-prod-clone", now)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, other)
+
+	id, err = cloneIdentifier(strings.Repeat("a", 48)+"-"+strings.Repeat("b", 20), now)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(id), 63)
+	assert.NotContains(t, id, "--")
+
+	// instance identifiers "<cluster>-<n>" stay within the limit as well
+	id, err = cloneIdentifier(strings.Repeat("c", 63), now)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(id), 63-len(cloneInstanceSuffix))
+	for _, input := range createDBInstanceInputs(&RestoreDBClusterFromSnapshotOpts{
+		DBClusterIdentifier: id,
+		InstanceClass:       "db.serverless",
+		InstanceCount:       99,
+	}) {
+		assert.LessOrEqual(t, len(aws.StringValue(input.DBInstanceIdentifier)), 63)
+	}
+}
+
+func TestIsExpiredClone(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	tags := func(clone, expiresAt string) []*rds.Tag {
+		return []*rds.Tag{
+			{Key: aws.String(CloneTagKey), Value: aws.String(clone)},
+			{Key: aws.String(CloneExpiresAtTagKey), Value: aws.String(expiresAt)},
+		}
+	}
+
+	assert.True(t, isExpiredClone(tags("true", "2024-06-29T23:59:59Z"), now))
+	assert.False(t, isExpiredClone(tags("true", "2024-06-30T00:00:01Z"), now))
+	assert.False(t, isExpiredClone(tags("false", "2024-06-29T23:59:59Z"), now))
+	assert.False(t, isExpiredClone(tags("true", "tomorrow"), now))
+	assert.False(t, isExpiredClone(nil, now))
+}
+
+func TestRemainingTimeout(t *testing.T) {
+	remaining, err := remainingTimeout(goctx.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), time.Minute)
+	defer cancel()
+	remaining, err = remainingTimeout(ctx)
+	assert.NoError(t, err)
+	assert.True(t, remaining > 0 && remaining <= time.Minute)
+
+	expired, cancelExpired := goctx.WithDeadline(goctx.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	_, err = remainingTimeout(expired)
+	assert.ErrorIs(t, err, goctx.DeadlineExceeded)
+}
@@ -0,0 +1,195 @@
+package rds
+
+import (
+	goctx "context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+var (
+	// ErrProtected the operation targets a protected cluster, instance or snapshot
+	ErrProtected = errors.New("rds: resource is protected")
+	// ErrConfirmationRequired the confirmation of a destructive operation does not match the target identifier
+	ErrConfirmationRequired = errors.New("rds: confirmation does not match the target identifier")
+)
+
+// GuardResourceType type of resource checked by the guard
+type GuardResourceType string
+
+const (
+	GuardResourceDBCluster         GuardResourceType = "db-cluster"
+	GuardResourceDBInstance        GuardResourceType = "db-instance"
+	GuardResourceDBClusterSnapshot GuardResourceType = "db-cluster-snapshot"
+	GuardResourceDBSnapshot        GuardResourceType = "db-snapshot"
+)
+
+// Guard safety layer every destructive operation of the service goes through
+type Guard struct {
+	// ProtectedIdentifiers identifiers which are never touched
+	ProtectedIdentifiers []string
+	// ProtectedPatterns identifiers matching any pattern are never touched
+	ProtectedPatterns []*regexp.Regexp
+	// ProtectedTagKey resources tagged ProtectedTagKey=ProtectedTagValue are never touched, empty disables the tag check
+	ProtectedTagKey   string
+	ProtectedTagValue string
+	// SkipConfirmation lets destructive operations run without a confirmation matching the target identifier
+	SkipConfirmation bool
+}
+
+// guardTarget resource a guarded operation acts on
+type guardTarget struct {
+	resourceType GuardResourceType
+	identifier   string
+	// destructive operations require confirmation to equal identifier
+	destructive  bool
+	confirmation string
+}
+
+// DefaultGuard guard protecting woodstock-prod and resources tagged protected=true
+func DefaultGuard() *Guard {
+	return &Guard{
+		ProtectedIdentifiers: []string{"woodstock-prod"},
+		ProtectedTagKey:      "protected",
+		ProtectedTagValue:    "true",
+	}
+}
+
+// SetGuard replaces the guard of the service, nil restores DefaultGuard
+func (s *Service) SetGuard(guard *Guard) {
+	if guard == nil {
+		guard = DefaultGuard()
+	}
+
+	s.guard = guard
+}
+
+// GetGuard get guard
+func (s *Service) GetGuard() *Guard {
+	if s.guard == nil {
+		return DefaultGuard()
+	}
+
+	return s.guard
+}
+
+// IsProtectedIdentifier whether the guard protects an identifier by name or pattern
+func (g *Guard) IsProtectedIdentifier(identifier string) bool {
+	for _, id := range g.ProtectedIdentifiers {
+		if id == identifier {
+			return true
+		}
+	}
+
+	for _, pattern := range g.ProtectedPatterns {
+		if pattern.MatchString(identifier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProtectedTagged whether tags mark a resource as protected
+func (g *Guard) isProtectedTagged(tags []*rds.Tag) bool {
+	if g.ProtectedTagKey == "" {
+		return false
+	}
+
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == g.ProtectedTagKey && aws.StringValue(tag.Value) == g.ProtectedTagValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkGuard refuses operations on protected resources and unconfirmed destructive operations
+func (s *Service) checkGuard(ctx goctx.Context, target *guardTarget) error {
+	guard := s.GetGuard()
+	if guard.IsProtectedIdentifier(target.identifier) {
+		return fmt.Errorf("%w: %s %s", ErrProtected, target.resourceType, target.identifier)
+	}
+
+	if target.destructive && !guard.SkipConfirmation && target.confirmation != target.identifier {
+		return fmt.Errorf("%w: %s %s", ErrConfirmationRequired, target.resourceType, target.identifier)
+	}
+
+	if guard.ProtectedTagKey == "" {
+		return nil
+	}
+
+	tags, err := s.resourceTags(ctx, target.resourceType, target.identifier)
+	if err != nil {
+		return err
+	}
+
+	if guard.isProtectedTagged(tags) {
+		return fmt.Errorf("%w: %s %s is tagged %s=%s", ErrProtected, target.resourceType, target.identifier, guard.ProtectedTagKey, guard.ProtectedTagValue)
+	}
+
+	return nil
+}
+
+// resourceTags tags of a resource, none when it does not exist
+func (s *Service) resourceTags(ctx goctx.Context, resourceType GuardResourceType, identifier string) ([]*rds.Tag, error) {
+	client := s.client()
+
+	var tags []*rds.Tag
+	var err error
+	switch resourceType {
+	case GuardResourceDBCluster:
+		var output *rds.DescribeDBClustersOutput
+		output, err = client.DescribeDBClustersWithContext(ctx, &rds.DescribeDBClustersInput{DBClusterIdentifier: aws.String(identifier)})
+		if err == nil && len(output.DBClusters) > 0 {
+			tags = output.DBClusters[0].TagList
+		}
+	case GuardResourceDBInstance:
+		var output *rds.DescribeDBInstancesOutput
+		output, err = client.DescribeDBInstancesWithContext(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(identifier)})
+		if err == nil && len(output.DBInstances) > 0 {
+			tags = output.DBInstances[0].TagList
+		}
+	case GuardResourceDBClusterSnapshot:
+		var output *rds.DescribeDBClusterSnapshotsOutput
+		output, err = client.DescribeDBClusterSnapshotsWithContext(ctx, &rds.DescribeDBClusterSnapshotsInput{DBClusterSnapshotIdentifier: aws.String(identifier)})
+		if err == nil && len(output.DBClusterSnapshots) > 0 {
+			tags = output.DBClusterSnapshots[0].TagList
+		}
+	case GuardResourceDBSnapshot:
+		var output *rds.DescribeDBSnapshotsOutput
+		output, err = client.DescribeDBSnapshotsWithContext(ctx, &rds.DescribeDBSnapshotsInput{DBSnapshotIdentifier: aws.String(identifier)})
+		if err == nil && len(output.DBSnapshots) > 0 {
+			tags = output.DBSnapshots[0].TagList
+		}
+	default:
+		return nil, fmt.Errorf("rds: unknown resource type %s", resourceType)
+	}
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	return tags, err
+}
+
+// isNotFound whether err reports a missing cluster, instance or snapshot
+func isNotFound(err error) bool {
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return false
+	}
+
+	switch aerr.Code() {
+	case rds.ErrCodeDBClusterNotFoundFault, rds.ErrCodeDBInstanceNotFoundFault,
+		rds.ErrCodeDBClusterSnapshotNotFoundFault, rds.ErrCodeDBSnapshotNotFoundFault:
+		return true
+	}
+
+	return false
+}
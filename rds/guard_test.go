@@ -0,0 +1,53 @@
+package rds
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardIsProtectedIdentifier(t *testing.T) {
+	guard := DefaultGuard()
+	assert.True(t, guard.IsProtectedIdentifier("woodstock-prod"))
+	assert.False(t, guard.IsProtectedIdentifier("woodstock-pre-prod"))
+
+	guard.ProtectedPatterns = []*regexp.Regexp{regexp.MustCompile(`-prod(-\d+)?$`)}
+	assert.True(t, guard.IsProtectedIdentifier("payments-prod-1"))
+	assert.False(t, guard.IsProtectedIdentifier("payments-staging"))
+}
+
+func TestGuardProtectedTag(t *testing.T) {
+	guard := DefaultGuard()
+	assert.True(t, guard.isProtectedTagged([]*rds.Tag{{Key: aws.String("protected"), Value: aws.String("true")}}))
+	assert.False(t, guard.isProtectedTagged([]*rds.Tag{{Key: aws.String("protected"), Value: aws.String("false")}}))
+
+	guard.ProtectedTagKey = ""
+	assert.False(t, guard.isProtectedTagged([]*rds.Tag{{Key: aws.String("protected"), Value: aws.String("true")}}))
+}
+
+func TestCheckGuard(t *testing.T) {
+	s := NewService("", "")
+	s.SetGuard(&Guard{ProtectedIdentifiers: []string{"woodstock-prod"}})
+	ctx := goctx.Background()
+
+	err := s.checkGuard(ctx, &guardTarget{resourceType: GuardResourceDBCluster, identifier: "woodstock-prod"})
+	assert.True(t, errors.Is(err, ErrProtected))
+
+	err = s.checkGuard(ctx, &guardTarget{resourceType: GuardResourceDBCluster, identifier: "woodstock-qa", destructive: true})
+	assert.True(t, errors.Is(err, ErrConfirmationRequired))
+
+	err = s.checkGuard(ctx, &guardTarget{resourceType: GuardResourceDBCluster, identifier: "woodstock-qa", destructive: true, confirmation: "woodstock-qa"})
+	assert.NoError(t, err)
+
+	s.SetGuard(&Guard{SkipConfirmation: true})
+	assert.NoError(t, s.checkGuard(ctx, &guardTarget{resourceType: GuardResourceDBCluster, identifier: "woodstock-qa", destructive: true}))
+
+	s.SetGuard(nil)
+	assert.Equal(t, DefaultGuard(), s.GetGuard())
+}
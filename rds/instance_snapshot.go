@@ -0,0 +1,148 @@
+package rds
+
+import (
+	goctx "context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// DescribeDBInstanceSnapshotsOpts describe db instance snapshots options, zero filters match all snapshots
+type DescribeDBInstanceSnapshotsOpts struct {
+	// DBInstanceIdentifier empty lists the snapshots of every instance
+	DBInstanceIdentifier string
+	// SnapshotType defaults to DBSnapshotsTypeAutomated, as do unknown types
+	SnapshotType  DBSnapshotsType
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Status        string
+	Engine        string
+	// Timeout of the whole listing
+	Timeout time.Duration
+}
+
+// DescribeDBInstanceSnapshotsResponse describe db instance snapshots response
+type DescribeDBInstanceSnapshotsResponse struct {
+	DBInstanceIdentifier string
+	Snapshots            []*rds.DBSnapshot
+	Error                error
+}
+
+// snapshotFilter client side snapshot filters the describe APIs do not support
+type snapshotFilter struct {
+	createdAfter  time.Time
+	createdBefore time.Time
+	status        string
+}
+
+// DescribeDBInstanceSnapshots describe snapshots of plain, non aurora, db instances, following every page
+func (s *Service) DescribeDBInstanceSnapshots(opts *DescribeDBInstanceSnapshotsOpts) (resp *DescribeDBInstanceSnapshotsResponse) {
+	s.context.check()
+	resp = &DescribeDBInstanceSnapshotsResponse{
+		DBInstanceIdentifier: opts.DBInstanceIdentifier,
+		Snapshots:            []*rds.DBSnapshot{},
+	}
+
+	client := s.client()
+	t := 180 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	input := &rds.DescribeDBSnapshotsInput{
+		Filters: engineFilters(opts.Engine),
+	}
+	input.SnapshotType, input.IncludeShared, input.IncludePublic = snapshotTypeInput(opts.SnapshotType)
+	if opts.DBInstanceIdentifier != "" {
+		input.DBInstanceIdentifier = aws.String(opts.DBInstanceIdentifier)
+	}
+
+	filter := &snapshotFilter{createdAfter: opts.CreatedAfter, createdBefore: opts.CreatedBefore, status: opts.Status}
+	err := client.DescribeDBSnapshotsPagesWithContext(ctx, input, func(page *rds.DescribeDBSnapshotsOutput, _ bool) bool {
+		for _, snapshot := range page.DBSnapshots {
+			if filter.matches(snapshot.SnapshotCreateTime, aws.StringValue(snapshot.Status)) {
+				resp.Snapshots = append(resp.Snapshots, snapshot)
+			}
+		}
+		return true
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// DescribeLatestDBInstanceSnapshot describe latest db instance snapshot
+func (s *Service) DescribeLatestDBInstanceSnapshot(opts *DescribeDBInstanceSnapshotsOpts) (snapshot *rds.DBSnapshot, err error) {
+	snapshotsResp := s.DescribeDBInstanceSnapshots(opts)
+	if snapshotsResp.Error != nil {
+		return nil, snapshotsResp.Error
+	}
+
+	for _, candidate := range snapshotsResp.Snapshots {
+		if candidate.SnapshotCreateTime == nil {
+			continue
+		}
+
+		if snapshot == nil || candidate.SnapshotCreateTime.After(*snapshot.SnapshotCreateTime) {
+			snapshot = candidate
+		}
+	}
+
+	return snapshot, nil
+}
+
+// matches whether a snapshot passes the filters
+func (f *snapshotFilter) matches(created *time.Time, status string) bool {
+	if f.status != "" && status != f.status {
+		return false
+	}
+
+	if f.createdAfter.IsZero() && f.createdBefore.IsZero() {
+		return true
+	}
+
+	if created == nil {
+		return false
+	}
+
+	if !f.createdAfter.IsZero() && created.Before(f.createdAfter) {
+		return false
+	}
+
+	if !f.createdBefore.IsZero() && !created.Before(f.createdBefore) {
+		return false
+	}
+
+	return true
+}
+
+// snapshotTypeInput snapshot type parameters, shared and public snapshots must be included explicitly.
+// Empty and unknown types fall back to automated
+func snapshotTypeInput(snapshotType DBSnapshotsType) (*string, *bool, *bool) {
+	switch snapshotType {
+	case DBSnapshotsTypeManual, DBSnapshotsTypeAutomated, DBSnapshotsTypeAWSBackup:
+		return aws.String(string(snapshotType)), nil, nil
+	case DBSnapshotsTypeShared:
+		return aws.String(string(snapshotType)), aws.Bool(true), nil
+	case DBSnapshotsTypePublic:
+		return aws.String(string(snapshotType)), nil, aws.Bool(true)
+	default:
+		return aws.String(string(DBSnapshotsTypeAutomated)), nil, nil
+	}
+}
+
+// engineFilters server side engine filter
+func engineFilters(engine string) []*rds.Filter {
+	if engine == "" {
+		return nil
+	}
+
+	return []*rds.Filter{{Name: aws.String("engine"), Values: aws.StringSlice([]string{engine})}}
+}
@@ -0,0 +1,58 @@
+package rds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotFilter(t *testing.T) {
+	created := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, new(snapshotFilter).matches(nil, "creating"))
+	assert.True(t, (&snapshotFilter{status: "available"}).matches(&created, "available"))
+	assert.False(t, (&snapshotFilter{status: "available"}).matches(&created, "creating"))
+
+	june := &snapshotFilter{
+		createdAfter:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		createdBefore: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	assert.True(t, june.matches(&created, "available"))
+	assert.False(t, june.matches(nil, "creating"))
+
+	july := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, june.matches(&july, "available"))
+	assert.False(t, (&snapshotFilter{createdAfter: july}).matches(&created, "available"))
+}
+
+func TestSnapshotTypeInput(t *testing.T) {
+	snapshotType, shared, public := snapshotTypeInput("")
+	assert.Equal(t, "automated", aws.StringValue(snapshotType))
+	assert.Nil(t, shared)
+	assert.Nil(t, public)
+
+	snapshotType, shared, public = snapshotTypeInput(DBSnapshotsTypeShared)
+	assert.Equal(t, "shared", aws.StringValue(snapshotType))
+	assert.True(t, aws.BoolValue(shared))
+	assert.Nil(t, public)
+
+	snapshotType, shared, public = snapshotTypeInput(DBSnapshotsTypePublic)
+	assert.Equal(t, "public", aws.StringValue(snapshotType))
+	assert.Nil(t, shared)
+	assert.True(t, aws.BoolValue(public))
+
+	for _, valid := range []DBSnapshotsType{DBSnapshotsTypeManual, DBSnapshotsTypeAutomated, DBSnapshotsTypeAWSBackup} {
+		snapshotType, shared, public = snapshotTypeInput(valid)
+		assert.Equal(t, string(valid), aws.StringValue(snapshotType))
+		assert.Nil(t, shared)
+		assert.Nil(t, public)
+	}
+
+	// unknown types are coerced to automated like the original implementation
+	snapshotType, shared, public = snapshotTypeInput("Manual")
+	assert.Equal(t, "automated", aws.StringValue(snapshotType))
+	assert.Nil(t, shared)
+	assert.Nil(t, public)
+}
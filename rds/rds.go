@@ -2,8 +2,6 @@ package rds
 
 import (
 	goctx "context"
-	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -24,13 +22,30 @@ type DBSnapshotsType string
 const (
 	DBSnapshotsTypeAutomated DBSnapshotsType = "automated"
 	DBSnapshotsTypeManual    DBSnapshotsType = "manual"
+	// DBSnapshotsTypeShared manual snapshots shared with this account by other accounts
+	DBSnapshotsTypeShared DBSnapshotsType = "shared"
+	// DBSnapshotsTypePublic manual snapshots public to all accounts
+	DBSnapshotsTypePublic DBSnapshotsType = "public"
+	// DBSnapshotsTypeAWSBackup snapshots managed by AWS Backup
+	DBSnapshotsTypeAWSBackup DBSnapshotsType = "awsbackup"
 )
 
-// DescribeDBClusterSnapshotsOpts describe db snapshot options
+// DescribeDBClusterSnapshotsOpts describe db snapshot options, zero filters match all snapshots
 type DescribeDBClusterSnapshotsOpts struct {
+	// DBClusterIdentifier empty lists the snapshots of every cluster
 	DBClusterIdentifier string
-	SnapshotType        DBSnapshotsType
-	Timeout             time.Duration
+	// SnapshotType defaults to DBSnapshotsTypeAutomated, as do unknown types
+	SnapshotType DBSnapshotsType
+	// CreatedAfter, CreatedBefore creation time range, snapshots still being created have no creation time
+	// and are excluded when either is set
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Status such as "available"
+	Status string
+	// Engine such as "aurora-mysql"
+	Engine string
+	// Timeout of the whole listing
+	Timeout time.Duration
 }
 
 // DescribeDBClusterSnapshotsResponse describe db snapshot response
@@ -44,12 +59,44 @@ type DescribeDBClusterSnapshotsResponse struct {
 type RestoreDBClusterFromSnapshotOpts struct {
 	DBClusterIdentifier string
 	SnapshotIdentifier  string
-	Timeout             time.Duration
+	// DBClusterParameterGroupName defaults to DefaultDBClusterParameterGroupName
+	DBClusterParameterGroupName string
+	// DBSubnetGroupName defaults to DefaultDBSubnetGroupName
+	DBSubnetGroupName string
+	// Engine defaults to DefaultEngine
+	Engine string
+	// EngineVersion defaults to the engine version of the snapshot
+	EngineVersion       string
+	VpcSecurityGroupIDs []string
+	// KmsKeyID re-encrypts the restored cluster, defaults to the key of the snapshot
+	KmsKeyID            string
+	Tag                 map[string]string
+	ServerlessV2Scaling *ServerlessV2ScalingOpts
+	// InstanceClass class of the instances to create, "db.serverless" for serverless v2,
+	// empty leaves the cluster without instances and returns without waiting
+	InstanceClass string
+	// InstanceCount number of instances to create, defaults to 1 when InstanceClass is set
+	InstanceCount int
+	// Wait polling of the created instances
+	Wait *WaitOpts
+	// Timeout of the whole workflow including waiting for the instances
+	Timeout time.Duration
+}
+
+// ServerlessV2ScalingOpts aurora serverless v2 capacity range in ACUs
+type ServerlessV2ScalingOpts struct {
+	MinCapacity float64
+	MaxCapacity float64
 }
 
 // RestoreDBClusterFromSnapshotResponse restore db instance from db snapshot response
 type RestoreDBClusterFromSnapshotResponse struct {
-	Error error
+	DBClusterIdentifier   string
+	DBInstanceIdentifiers []string
+	// Endpoint writer endpoint
+	Endpoint       string
+	ReaderEndpoint string
+	Error          error
 }
 
 // Context context includes endpoint, region and bucket info
@@ -62,6 +109,7 @@ type Service struct {
 	context      *context
 	accessKey    string
 	accessSecret string
+	guard        *Guard
 }
 
 // NewService service initializer
@@ -70,6 +118,7 @@ func NewService(key, secret string) *Service {
 		context:      new(context),
 		accessKey:    key,
 		accessSecret: secret,
+		guard:        DefaultGuard(),
 	}
 }
 
@@ -101,11 +150,12 @@ func (s *Service) client() *rds.RDS {
 	return instance
 }
 
-// DescribeDBSnapshots describe db snapshots
+// DescribeDBSnapshots describe db cluster snapshots, following every page
 func (s *Service) DescribeDBSnapshots(opts *DescribeDBClusterSnapshotsOpts) (resp *DescribeDBClusterSnapshotsResponse) {
 	s.context.check()
 	resp = &DescribeDBClusterSnapshotsResponse{
-		Snapshots: []*rds.DBClusterSnapshot{},
+		DBClusterIdentifier: opts.DBClusterIdentifier,
+		Snapshots:           []*rds.DBClusterSnapshot{},
 	}
 
 	client := s.client()
@@ -114,23 +164,29 @@ func (s *Service) DescribeDBSnapshots(opts *DescribeDBClusterSnapshotsOpts) (res
 		t = opts.Timeout
 	}
 
-	snapshotType := string(DBSnapshotsTypeAutomated)
-	if opts.SnapshotType == DBSnapshotsTypeManual {
-		snapshotType = string(DBSnapshotsTypeManual)
-	}
-
 	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
 	defer cancel()
 
-	snapshotResp, err := client.DescribeDBClusterSnapshotsWithContext(ctx, &rds.DescribeDBClusterSnapshotsInput{
-		DBClusterIdentifier: aws.String(opts.DBClusterIdentifier),
-		SnapshotType:        aws.String(snapshotType),
+	input := &rds.DescribeDBClusterSnapshotsInput{
+		Filters: engineFilters(opts.Engine),
+	}
+	input.SnapshotType, input.IncludeShared, input.IncludePublic = snapshotTypeInput(opts.SnapshotType)
+	if opts.DBClusterIdentifier != "" {
+		input.DBClusterIdentifier = aws.String(opts.DBClusterIdentifier)
+	}
+
+	filter := &snapshotFilter{createdAfter: opts.CreatedAfter, createdBefore: opts.CreatedBefore, status: opts.Status}
+	err := client.DescribeDBClusterSnapshotsPagesWithContext(ctx, input, func(page *rds.DescribeDBClusterSnapshotsOutput, _ bool) bool {
+		for _, snapshot := range page.DBClusterSnapshots {
+			if filter.matches(snapshot.SnapshotCreateTime, aws.StringValue(snapshot.Status)) {
+				resp.Snapshots = append(resp.Snapshots, snapshot)
+			}
+		}
+		return true
 	})
 
 	if err != nil {
 		resp.Error = err
-	} else {
-		resp.Snapshots = snapshotResp.DBClusterSnapshots
 	}
 
 	return
@@ -143,24 +199,24 @@ func (s *Service) DescribeLatestDBSnapshot(opts *DescribeDBClusterSnapshotsOpts)
 		return nil, snapshotsResp.Error
 	}
 
-	if len(snapshotsResp.Snapshots) == 0 {
-		return nil, nil
-	}
+	for _, candidate := range snapshotsResp.Snapshots {
+		if candidate.SnapshotCreateTime == nil {
+			continue
+		}
 
-	sort.Slice(snapshotsResp.Snapshots, func(i, j int) bool {
-		return snapshotsResp.Snapshots[i].SnapshotCreateTime.After(*snapshotsResp.Snapshots[j].SnapshotCreateTime)
-	})
+		if snapshot == nil || candidate.SnapshotCreateTime.After(*snapshot.SnapshotCreateTime) {
+			snapshot = candidate
+		}
+	}
 
-	return snapshotsResp.Snapshots[0], nil
+	return snapshot, nil
 }
 
-// RestoreDBClusterFromSnapshot restore db instance from db snapshot
+// RestoreDBClusterFromSnapshot restore db instance from db snapshot, then create its instances and wait until they are available
 func (s *Service) RestoreDBClusterFromSnapshot(opts *RestoreDBClusterFromSnapshotOpts) (resp *RestoreDBClusterFromSnapshotResponse) {
 	s.context.check()
-	if opts.DBClusterIdentifier == "woodstock-prod" {
-		return &RestoreDBClusterFromSnapshotResponse{
-			Error: fmt.Errorf("woodstock-prod db cluster cannot be restored"),
-		}
+	resp = &RestoreDBClusterFromSnapshotResponse{
+		DBClusterIdentifier: opts.DBClusterIdentifier,
 	}
 
 	client := s.client()
@@ -172,16 +228,47 @@ func (s *Service) RestoreDBClusterFromSnapshot(opts *RestoreDBClusterFromSnapsho
 	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
 	defer cancel()
 
-	_, err := client.RestoreDBClusterFromSnapshotWithContext(ctx, &rds.RestoreDBClusterFromSnapshotInput{
-		DBClusterIdentifier:         aws.String(opts.DBClusterIdentifier),
-		SnapshotIdentifier:          aws.String(opts.SnapshotIdentifier),
-		DBClusterParameterGroupName: aws.String(DefaultDBClusterParameterGroupName),
-		DBSubnetGroupName:           aws.String(DefaultDBSubnetGroupName),
-		Engine:                      aws.String(DefaultEngine),
-	})
+	if err := s.checkGuard(ctx, &guardTarget{resourceType: GuardResourceDBCluster, identifier: opts.DBClusterIdentifier}); err != nil {
+		resp.Error = err
+		return
+	}
 
+	output, err := client.RestoreDBClusterFromSnapshotWithContext(ctx, restoreDBClusterFromSnapshotInput(opts))
 	if err != nil {
 		resp.Error = err
+		return
+	}
+
+	resp.Endpoint = aws.StringValue(output.DBCluster.Endpoint)
+	resp.ReaderEndpoint = aws.StringValue(output.DBCluster.ReaderEndpoint)
+	if opts.InstanceClass == "" {
+		return
+	}
+
+	resp.DBInstanceIdentifiers, err = s.createClusterInstances(ctx, opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	for _, id := range resp.DBInstanceIdentifiers {
+		if err = s.WaitDBInstanceAvailable(ctx, id, opts.Wait); err != nil {
+			resp.Error = err
+			return
+		}
+	}
+
+	clusters, err := client.DescribeDBClustersWithContext(ctx, &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(opts.DBClusterIdentifier),
+	})
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	if len(clusters.DBClusters) > 0 {
+		resp.Endpoint = aws.StringValue(clusters.DBClusters[0].Endpoint)
+		resp.ReaderEndpoint = aws.StringValue(clusters.DBClusters[0].ReaderEndpoint)
 	}
 
 	return
@@ -0,0 +1,96 @@
+package rds
+
+import (
+	goctx "context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// restoreDBClusterFromSnapshotInput restore input with the package defaults filled in
+func restoreDBClusterFromSnapshotInput(opts *RestoreDBClusterFromSnapshotOpts) *rds.RestoreDBClusterFromSnapshotInput {
+	input := &rds.RestoreDBClusterFromSnapshotInput{
+		DBClusterIdentifier:         aws.String(opts.DBClusterIdentifier),
+		SnapshotIdentifier:          aws.String(opts.SnapshotIdentifier),
+		DBClusterParameterGroupName: aws.String(stringOr(opts.DBClusterParameterGroupName, DefaultDBClusterParameterGroupName)),
+		DBSubnetGroupName:           aws.String(stringOr(opts.DBSubnetGroupName, DefaultDBSubnetGroupName)),
+		Engine:                      aws.String(stringOr(opts.Engine, DefaultEngine)),
+		Tags:                        toTags(opts.Tag),
+	}
+
+	if opts.EngineVersion != "" {
+		input.EngineVersion = aws.String(opts.EngineVersion)
+	}
+
+	if len(opts.VpcSecurityGroupIDs) > 0 {
+		input.VpcSecurityGroupIds = aws.StringSlice(opts.VpcSecurityGroupIDs)
+	}
+
+	if opts.KmsKeyID != "" {
+		input.KmsKeyId = aws.String(opts.KmsKeyID)
+	}
+
+	if opts.ServerlessV2Scaling != nil {
+		input.ServerlessV2ScalingConfiguration = &rds.ServerlessV2ScalingConfiguration{
+			MinCapacity: aws.Float64(opts.ServerlessV2Scaling.MinCapacity),
+			MaxCapacity: aws.Float64(opts.ServerlessV2Scaling.MaxCapacity),
+		}
+	}
+
+	return input
+}
+
+// createDBInstanceInputs inputs of the instances of a restored cluster, named <cluster>-1, <cluster>-2...
+func createDBInstanceInputs(opts *RestoreDBClusterFromSnapshotOpts) []*rds.CreateDBInstanceInput {
+	count := opts.InstanceCount
+	if count <= 0 {
+		count = 1
+	}
+
+	inputs := make([]*rds.CreateDBInstanceInput, 0, count)
+	for i := 1; i <= count; i++ {
+		inputs = append(inputs, &rds.CreateDBInstanceInput{
+			DBInstanceIdentifier: aws.String(fmt.Sprintf("%s-%d", opts.DBClusterIdentifier, i)),
+			DBClusterIdentifier:  aws.String(opts.DBClusterIdentifier),
+			DBInstanceClass:      aws.String(opts.InstanceClass),
+			Engine:               aws.String(stringOr(opts.Engine, DefaultEngine)),
+			Tags:                 toTags(opts.Tag),
+		})
+	}
+
+	return inputs
+}
+
+// createClusterInstances creates the instances of a restored cluster, returning the identifiers created so far on error
+func (s *Service) createClusterInstances(ctx goctx.Context, opts *RestoreDBClusterFromSnapshotOpts) ([]string, error) {
+	var ids []string
+	for _, input := range createDBInstanceInputs(opts) {
+		if _, err := s.client().CreateDBInstanceWithContext(ctx, input); err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, aws.StringValue(input.DBInstanceIdentifier))
+	}
+
+	return ids, nil
+}
+
+// toTags rds tags from a map
+func toTags(tags map[string]string) []*rds.Tag {
+	var result []*rds.Tag
+	for k, v := range tags {
+		result = append(result, &rds.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	return result
+}
+
+// stringOr s, or fallback when s is empty
+func stringOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+
+	return s
+}
@@ -0,0 +1,65 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreDBClusterFromSnapshotInput(t *testing.T) {
+	input := restoreDBClusterFromSnapshotInput(&RestoreDBClusterFromSnapshotOpts{
+		DBClusterIdentifier: "woodstock-pre-prod",
+		SnapshotIdentifier:  "rds:woodstock-prod-2024-01-01-00-00",
+	})
+	assert.Equal(t, DefaultDBClusterParameterGroupName, aws.StringValue(input.DBClusterParameterGroupName))
+	assert.Equal(t, DefaultDBSubnetGroupName, aws.StringValue(input.DBSubnetGroupName))
+	assert.Equal(t, DefaultEngine, aws.StringValue(input.Engine))
+	assert.Nil(t, input.ServerlessV2ScalingConfiguration)
+
+	input = restoreDBClusterFromSnapshotInput(&RestoreDBClusterFromSnapshotOpts{
+		DBClusterIdentifier:         "woodstock-staging",
+		SnapshotIdentifier:          "rds:woodstock-prod-2024-01-01-00-00",
+		DBClusterParameterGroupName: "woodstock-aurora-cluster-staging",
+		DBSubnetGroupName:           "main_subnet_group_staging",
+		Engine:                      "aurora-postgresql",
+		EngineVersion:               "15.4",
+		VpcSecurityGroupIDs:         []string{"sg-123"},
+		KmsKeyID:                    "alias/staging",
+		Tag:                         map[string]string{"env": "staging"},
+		ServerlessV2Scaling:         &ServerlessV2ScalingOpts{MinCapacity: 0.5, MaxCapacity: 4},
+	})
+	assert.Equal(t, "woodstock-aurora-cluster-staging", aws.StringValue(input.DBClusterParameterGroupName))
+	assert.Equal(t, "main_subnet_group_staging", aws.StringValue(input.DBSubnetGroupName))
+	assert.Equal(t, "aurora-postgresql", aws.StringValue(input.Engine))
+	assert.Equal(t, "15.4", aws.StringValue(input.EngineVersion))
+	assert.Equal(t, []string{"sg-123"}, aws.StringValueSlice(input.VpcSecurityGroupIds))
+	assert.Equal(t, "alias/staging", aws.StringValue(input.KmsKeyId))
+	assert.Equal(t, "env", aws.StringValue(input.Tags[0].Key))
+	assert.Equal(t, 0.5, aws.Float64Value(input.ServerlessV2ScalingConfiguration.MinCapacity))
+	assert.Equal(t, 4.0, aws.Float64Value(input.ServerlessV2ScalingConfiguration.MaxCapacity))
+}
+
+func TestCreateDBInstanceInputs(t *testing.T) {
+	inputs := createDBInstanceInputs(&RestoreDBClusterFromSnapshotOpts{
+		DBClusterIdentifier: "woodstock-pre-prod",
+		InstanceClass:       "db.serverless",
+	})
+	assert.Len(t, inputs, 1)
+	assert.Equal(t, "woodstock-pre-prod-1", aws.StringValue(inputs[0].DBInstanceIdentifier))
+	assert.Equal(t, "woodstock-pre-prod", aws.StringValue(inputs[0].DBClusterIdentifier))
+	assert.Equal(t, "db.serverless", aws.StringValue(inputs[0].DBInstanceClass))
+	assert.Equal(t, DefaultEngine, aws.StringValue(inputs[0].Engine))
+
+	inputs = createDBInstanceInputs(&RestoreDBClusterFromSnapshotOpts{
+		DBClusterIdentifier: "woodstock-staging",
+		InstanceClass:       "db.r6g.large",
+		InstanceCount:       3,
+		Engine:              "aurora-postgresql",
+	})
+	assert.Len(t, inputs, 3)
+	for i, id := range []string{"woodstock-staging-1", "woodstock-staging-2", "woodstock-staging-3"} {
+		assert.Equal(t, id, aws.StringValue(inputs[i].DBInstanceIdentifier))
+		assert.Equal(t, "aurora-postgresql", aws.StringValue(inputs[i].Engine))
+	}
+}
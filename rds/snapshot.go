@@ -0,0 +1,359 @@
+package rds
+
+import (
+	goctx "context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// CreateDBClusterSnapshotOpts create manual db cluster snapshot options
+type CreateDBClusterSnapshotOpts struct {
+	DBClusterIdentifier string
+	SnapshotIdentifier  string
+	Tag                 map[string]string
+	Timeout             time.Duration
+}
+
+// CopyDBClusterSnapshotOpts copy db cluster snapshot options, the copy is created in the service region
+type CopyDBClusterSnapshotOpts struct {
+	// SourceSnapshotIdentifier snapshot identifier, or its ARN when copying from another region
+	SourceSnapshotIdentifier string
+	TargetSnapshotIdentifier string
+	// SourceRegion region of the source snapshot for cross region copies, empty copies within the service region
+	SourceRegion string
+	// KmsKeyID key of the target region encrypting the copy, required for encrypted cross region copies
+	KmsKeyID string
+	CopyTags bool
+	Tag      map[string]string
+	Timeout  time.Duration
+}
+
+// ShareDBClusterSnapshotOpts share db cluster snapshot options
+type ShareDBClusterSnapshotOpts struct {
+	SnapshotIdentifier string
+	// AccountIDs accounts allowed to restore the snapshot, "all" makes it public
+	AccountIDs []string
+	// RevokeAccountIDs accounts no longer allowed to restore the snapshot
+	RevokeAccountIDs []string
+	// Confirmation must equal SnapshotIdentifier when AccountIDs contains "all", see Guard
+	Confirmation string
+	Timeout      time.Duration
+}
+
+// DeleteDBClusterSnapshotOpts delete db cluster snapshot options
+type DeleteDBClusterSnapshotOpts struct {
+	SnapshotIdentifier string
+	// Confirmation must equal SnapshotIdentifier, see Guard
+	Confirmation string
+	Timeout      time.Duration
+}
+
+// PruneDBClusterSnapshotsOpts prune manual db cluster snapshots options
+type PruneDBClusterSnapshotsOpts struct {
+	DBClusterIdentifier string
+	// MaxAge manual snapshots older than MaxAge are deleted
+	MaxAge time.Duration
+	// KeepLatest number of latest snapshots kept regardless of their age
+	KeepLatest int
+	// DryRun lists the snapshots which would be deleted without deleting them
+	DryRun bool
+	// Confirmation must equal DBClusterIdentifier, see Guard
+	Confirmation string
+	Timeout      time.Duration
+}
+
+// DBClusterSnapshotResponse db cluster snapshot response
+type DBClusterSnapshotResponse struct {
+	Snapshot *rds.DBClusterSnapshot
+	Error    error
+}
+
+// ShareDBClusterSnapshotResponse share db cluster snapshot response
+type ShareDBClusterSnapshotResponse struct {
+	// AccountIDs accounts allowed to restore the snapshot after the change
+	AccountIDs []string
+	Error      error
+}
+
+// PruneDBClusterSnapshotsResponse prune manual db cluster snapshots response
+type PruneDBClusterSnapshotsResponse struct {
+	// Deleted snapshot identifiers deleted, or to be deleted on dry run
+	Deleted []string
+	// Skipped snapshot identifiers due for deletion but protected by the guard
+	Skipped []string
+	Error   error
+}
+
+// CreateDBClusterSnapshot creates a manual db cluster snapshot
+func (s *Service) CreateDBClusterSnapshot(opts *CreateDBClusterSnapshotOpts) (resp *DBClusterSnapshotResponse) {
+	s.context.check()
+	resp = new(DBClusterSnapshotResponse)
+
+	t := 180 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := s.client().CreateDBClusterSnapshotWithContext(ctx, &rds.CreateDBClusterSnapshotInput{
+		DBClusterIdentifier:         aws.String(opts.DBClusterIdentifier),
+		DBClusterSnapshotIdentifier: aws.String(opts.SnapshotIdentifier),
+		Tags:                        toTags(opts.Tag),
+	})
+
+	if err != nil {
+		resp.Error = err
+	} else {
+		resp.Snapshot = output.DBClusterSnapshot
+	}
+
+	return
+}
+
+// CopyDBClusterSnapshot copies a db cluster snapshot into the service region, re-encrypting it with KmsKeyID
+func (s *Service) CopyDBClusterSnapshot(opts *CopyDBClusterSnapshotOpts) (resp *DBClusterSnapshotResponse) {
+	s.context.check()
+	resp = new(DBClusterSnapshotResponse)
+
+	input := &rds.CopyDBClusterSnapshotInput{
+		SourceDBClusterSnapshotIdentifier: aws.String(opts.SourceSnapshotIdentifier),
+		TargetDBClusterSnapshotIdentifier: aws.String(opts.TargetSnapshotIdentifier),
+		CopyTags:                          aws.Bool(opts.CopyTags),
+		Tags:                              toTags(opts.Tag),
+	}
+
+	// the sdk presigns the copy request in the source region when SourceRegion is set
+	if opts.SourceRegion != "" && opts.SourceRegion != s.GetRegion() {
+		input.SourceRegion = aws.String(opts.SourceRegion)
+	}
+
+	if opts.KmsKeyID != "" {
+		input.KmsKeyId = aws.String(opts.KmsKeyID)
+	}
+
+	t := 180 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := s.client().CopyDBClusterSnapshotWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+	} else {
+		resp.Snapshot = output.DBClusterSnapshot
+	}
+
+	return
+}
+
+// ShareDBClusterSnapshot allows, or revokes, other accounts to restore a manual db cluster snapshot.
+// Sharing is subject to the guard, making the snapshot public also requires confirmation
+func (s *Service) ShareDBClusterSnapshot(opts *ShareDBClusterSnapshotOpts) (resp *ShareDBClusterSnapshotResponse) {
+	s.context.check()
+	resp = new(ShareDBClusterSnapshotResponse)
+
+	t := 180 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	// revoking only narrows access and is always allowed
+	if len(opts.AccountIDs) > 0 {
+		err := s.checkGuard(ctx, &guardTarget{
+			resourceType: GuardResourceDBClusterSnapshot,
+			identifier:   opts.SnapshotIdentifier,
+			destructive:  isPublicShare(opts.AccountIDs),
+			confirmation: opts.Confirmation,
+		})
+		if err != nil {
+			resp.Error = err
+			return
+		}
+	}
+
+	input := &rds.ModifyDBClusterSnapshotAttributeInput{
+		DBClusterSnapshotIdentifier: aws.String(opts.SnapshotIdentifier),
+		AttributeName:               aws.String("restore"),
+	}
+
+	if len(opts.AccountIDs) > 0 {
+		input.ValuesToAdd = aws.StringSlice(opts.AccountIDs)
+	}
+
+	if len(opts.RevokeAccountIDs) > 0 {
+		input.ValuesToRemove = aws.StringSlice(opts.RevokeAccountIDs)
+	}
+
+	output, err := s.client().ModifyDBClusterSnapshotAttributeWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	if output.DBClusterSnapshotAttributesResult != nil {
+		for _, attr := range output.DBClusterSnapshotAttributesResult.DBClusterSnapshotAttributes {
+			if aws.StringValue(attr.AttributeName) == "restore" {
+				resp.AccountIDs = aws.StringValueSlice(attr.AttributeValues)
+			}
+		}
+	}
+
+	return
+}
+
+// DeleteDBClusterSnapshot deletes a manual db cluster snapshot, subject to the guard
+func (s *Service) DeleteDBClusterSnapshot(opts *DeleteDBClusterSnapshotOpts) (resp *DBClusterSnapshotResponse) {
+	s.context.check()
+	resp = new(DBClusterSnapshotResponse)
+
+	t := 180 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	resp.Snapshot, resp.Error = s.deleteDBClusterSnapshot(ctx, opts.SnapshotIdentifier, opts.Confirmation)
+	return
+}
+
+// PruneDBClusterSnapshots deletes manual snapshots of a cluster older than MaxAge, keeping the KeepLatest latest ones.
+// Snapshots protected by the guard are skipped
+func (s *Service) PruneDBClusterSnapshots(opts *PruneDBClusterSnapshotsOpts) (resp *PruneDBClusterSnapshotsResponse) {
+	s.context.check()
+	resp = new(PruneDBClusterSnapshotsResponse)
+
+	if opts.MaxAge <= 0 {
+		resp.Error = fmt.Errorf("rds: prune requires a positive MaxAge")
+		return
+	}
+
+	if !opts.DryRun && !s.GetGuard().SkipConfirmation && opts.Confirmation != opts.DBClusterIdentifier {
+		resp.Error = fmt.Errorf("%w: %s %s", ErrConfirmationRequired, GuardResourceDBCluster, opts.DBClusterIdentifier)
+		return
+	}
+
+	t := 180 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	var snapshots []*rds.DBClusterSnapshot
+	err := s.client().DescribeDBClusterSnapshotsPagesWithContext(ctx, &rds.DescribeDBClusterSnapshotsInput{
+		DBClusterIdentifier: aws.String(opts.DBClusterIdentifier),
+		SnapshotType:        aws.String(string(DBSnapshotsTypeManual)),
+	}, func(page *rds.DescribeDBClusterSnapshotsOutput, _ bool) bool {
+		snapshots = append(snapshots, page.DBClusterSnapshots...)
+		return true
+	})
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	for _, snapshot := range selectSnapshotsToPrune(snapshots, time.Now(), opts.MaxAge, opts.KeepLatest) {
+		id := aws.StringValue(snapshot.DBClusterSnapshotIdentifier)
+		if opts.DryRun {
+			resp.Deleted = append(resp.Deleted, id)
+			continue
+		}
+
+		// the prune confirmation covers every snapshot of the cluster
+		if _, err := s.deleteDBClusterSnapshot(ctx, id, id); err != nil {
+			if errors.Is(err, ErrProtected) {
+				resp.Skipped = append(resp.Skipped, id)
+				continue
+			}
+
+			resp.Error = err
+			return
+		}
+
+		resp.Deleted = append(resp.Deleted, id)
+	}
+
+	return
+}
+
+// isPublicShare whether sharing with accountIDs makes a snapshot public
+func isPublicShare(accountIDs []string) bool {
+	for _, id := range accountIDs {
+		if id == "all" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleteDBClusterSnapshot deletes a snapshot once the guard allows it
+func (s *Service) deleteDBClusterSnapshot(ctx goctx.Context, identifier, confirmation string) (*rds.DBClusterSnapshot, error) {
+	err := s.checkGuard(ctx, &guardTarget{
+		resourceType: GuardResourceDBClusterSnapshot,
+		identifier:   identifier,
+		destructive:  true,
+		confirmation: confirmation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.client().DeleteDBClusterSnapshotWithContext(ctx, &rds.DeleteDBClusterSnapshotInput{
+		DBClusterSnapshotIdentifier: aws.String(identifier),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.DBClusterSnapshot, nil
+}
+
+// selectSnapshotsToPrune snapshots older than maxAge at now, except the keep latest ones.
+// Snapshots still being created have no creation time and are never selected
+func selectSnapshotsToPrune(snapshots []*rds.DBClusterSnapshot, now time.Time, maxAge time.Duration, keep int) []*rds.DBClusterSnapshot {
+	var created []*rds.DBClusterSnapshot
+	for _, snapshot := range snapshots {
+		if snapshot.SnapshotCreateTime != nil {
+			created = append(created, snapshot)
+		}
+	}
+
+	sort.Slice(created, func(i, j int) bool {
+		return created[i].SnapshotCreateTime.After(*created[j].SnapshotCreateTime)
+	})
+
+	if keep < 0 {
+		keep = 0
+	}
+
+	if keep >= len(created) {
+		return nil
+	}
+
+	var pruned []*rds.DBClusterSnapshot
+	cutoff := now.Add(-maxAge)
+	for _, snapshot := range created[keep:] {
+		if snapshot.SnapshotCreateTime.Before(cutoff) {
+			pruned = append(pruned, snapshot)
+		}
+	}
+
+	return pruned
+}
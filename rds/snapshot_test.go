@@ -0,0 +1,61 @@
+package rds
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectSnapshotsToPrune(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	snapshot := func(id string, age time.Duration) *rds.DBClusterSnapshot {
+		return &rds.DBClusterSnapshot{DBClusterSnapshotIdentifier: aws.String(id), SnapshotCreateTime: aws.Time(now.Add(-age))}
+	}
+
+	snapshots := []*rds.DBClusterSnapshot{
+		snapshot("d40", 40*day),
+		snapshot("d1", day),
+		snapshot("d35", 35*day),
+		snapshot("d10", 10*day),
+		snapshot("d31", 31*day),
+		{DBClusterSnapshotIdentifier: aws.String("creating")},
+	}
+
+	ids := func(snapshots []*rds.DBClusterSnapshot) []string {
+		var result []string
+		for _, s := range snapshots {
+			result = append(result, aws.StringValue(s.DBClusterSnapshotIdentifier))
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"d31", "d35", "d40"}, ids(selectSnapshotsToPrune(snapshots, now, 30*day, 0)))
+	assert.Equal(t, []string{"d35", "d40"}, ids(selectSnapshotsToPrune(snapshots, now, 30*day, 3)))
+	assert.Empty(t, selectSnapshotsToPrune(snapshots, now, 30*day, 5))
+	assert.Empty(t, selectSnapshotsToPrune(snapshots, now, 60*day, 0))
+}
+
+func TestShareDBClusterSnapshotGuard(t *testing.T) {
+	s := NewService("", "")
+	s.SetGuard(&Guard{ProtectedIdentifiers: []string{"woodstock-prod-final"}})
+
+	resp := s.ShareDBClusterSnapshot(&ShareDBClusterSnapshotOpts{
+		SnapshotIdentifier: "woodstock-prod-final",
+		AccountIDs:         []string{"123456789012"},
+	})
+	assert.True(t, errors.Is(resp.Error, ErrProtected))
+
+	resp = s.ShareDBClusterSnapshot(&ShareDBClusterSnapshotOpts{
+		SnapshotIdentifier: "woodstock-qa-final",
+		AccountIDs:         []string{"all"},
+	})
+	assert.True(t, errors.Is(resp.Error, ErrConfirmationRequired))
+
+	assert.True(t, isPublicShare([]string{"123456789012", "all"}))
+	assert.False(t, isPublicShare([]string{"123456789012"}))
+}
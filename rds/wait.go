@@ -0,0 +1,189 @@
+package rds
+
+import (
+	goctx "context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// ErrWaitFailed the resource reached a state it cannot leave by itself
+var ErrWaitFailed = errors.New("rds: resource reached a failed state")
+
+// statusNotFound status reported while the resource does not exist
+const statusNotFound = "not-found"
+
+// failedStatuses cluster, instance and snapshot statuses a waiter gives up on
+var failedStatuses = map[string]bool{
+	"failed":                              true,
+	"inaccessible-encryption-credentials": true,
+	"incompatible-network":                true,
+	"incompatible-option-group":           true,
+	"incompatible-parameters":             true,
+	"incompatible-restore":                true,
+	"restore-error":                       true,
+	"storage-full":                        true,
+}
+
+// WaitOpts waiter options
+type WaitOpts struct {
+	// Backoff polling intervals, defaults to exponential backoff from 15 seconds up to 1 minute.
+	// The waiter stops with the context, or when Backoff stops
+	Backoff backoff.BackOff
+	// OnStatus called after every poll
+	OnStatus func(status *WaitStatus)
+}
+
+// WaitStatus status of a resource being waited for
+type WaitStatus struct {
+	Identifier string
+	// Status cluster, instance or snapshot status, "not-found" when it does not exist
+	Status  string
+	Attempt int
+	Elapsed time.Duration
+}
+
+// waitPoll describes the resource once, done when the wait is over
+type waitPoll func(ctx goctx.Context) (status string, done bool, err error)
+
+// WaitDBClusterAvailable waits until a db cluster is available
+func (s *Service) WaitDBClusterAvailable(ctx goctx.Context, identifier string, opts *WaitOpts) error {
+	return waitFor(ctx, identifier, opts, func(ctx goctx.Context) (string, bool, error) {
+		status, err := s.dbClusterStatus(ctx, identifier)
+		return status, status == "available", err
+	})
+}
+
+// WaitDBClusterDeleted waits until a db cluster no longer exists
+func (s *Service) WaitDBClusterDeleted(ctx goctx.Context, identifier string, opts *WaitOpts) error {
+	return waitFor(ctx, identifier, opts, func(ctx goctx.Context) (string, bool, error) {
+		status, err := s.dbClusterStatus(ctx, identifier)
+		return status, status == statusNotFound, err
+	})
+}
+
+// WaitDBInstanceAvailable waits until a db instance is available
+func (s *Service) WaitDBInstanceAvailable(ctx goctx.Context, identifier string, opts *WaitOpts) error {
+	return waitFor(ctx, identifier, opts, func(ctx goctx.Context) (string, bool, error) {
+		status, err := s.dbInstanceStatus(ctx, identifier)
+		return status, status == "available", err
+	})
+}
+
+// WaitDBInstanceDeleted waits until a db instance no longer exists
+func (s *Service) WaitDBInstanceDeleted(ctx goctx.Context, identifier string, opts *WaitOpts) error {
+	return waitFor(ctx, identifier, opts, func(ctx goctx.Context) (string, bool, error) {
+		status, err := s.dbInstanceStatus(ctx, identifier)
+		return status, status == statusNotFound, err
+	})
+}
+
+// WaitDBClusterSnapshotAvailable waits until a db cluster snapshot is available
+func (s *Service) WaitDBClusterSnapshotAvailable(ctx goctx.Context, identifier string, opts *WaitOpts) error {
+	return waitFor(ctx, identifier, opts, func(ctx goctx.Context) (string, bool, error) {
+		output, err := s.client().DescribeDBClusterSnapshotsWithContext(ctx, &rds.DescribeDBClusterSnapshotsInput{
+			DBClusterSnapshotIdentifier: aws.String(identifier),
+		})
+		if isNotFound(err) || (err == nil && len(output.DBClusterSnapshots) == 0) {
+			return statusNotFound, false, nil
+		}
+
+		if err != nil {
+			return "", false, err
+		}
+
+		status := aws.StringValue(output.DBClusterSnapshots[0].Status)
+		return status, status == "available", nil
+	})
+}
+
+// dbClusterStatus status of a db cluster
+func (s *Service) dbClusterStatus(ctx goctx.Context, identifier string) (string, error) {
+	output, err := s.client().DescribeDBClustersWithContext(ctx, &rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(identifier),
+	})
+	if isNotFound(err) || (err == nil && len(output.DBClusters) == 0) {
+		return statusNotFound, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(output.DBClusters[0].Status), nil
+}
+
+// dbInstanceStatus status of a db instance
+func (s *Service) dbInstanceStatus(ctx goctx.Context, identifier string) (string, error) {
+	output, err := s.client().DescribeDBInstancesWithContext(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	})
+	if isNotFound(err) || (err == nil && len(output.DBInstances) == 0) {
+		return statusNotFound, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(output.DBInstances[0].DBInstanceStatus), nil
+}
+
+// waitFor polls until done, a failed status, an error, the context ending or the backoff stopping
+func waitFor(ctx goctx.Context, identifier string, opts *WaitOpts, poll waitPoll) error {
+	if opts == nil {
+		opts = new(WaitOpts)
+	}
+
+	b := opts.Backoff
+	if b == nil {
+		exp := backoff.NewExponentialBackOff()
+		exp.InitialInterval = 15 * time.Second
+		exp.MaxInterval = time.Minute
+		exp.MaxElapsedTime = 0
+		b = exp
+	}
+	b.Reset()
+	bctx := backoff.WithContext(b, ctx)
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		status, done, err := poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		if opts.OnStatus != nil {
+			opts.OnStatus(&WaitStatus{
+				Identifier: identifier,
+				Status:     status,
+				Attempt:    attempt,
+				Elapsed:    time.Since(start),
+			})
+		}
+
+		if done {
+			return nil
+		}
+
+		if failedStatuses[status] {
+			return fmt.Errorf("%w: %s is %s", ErrWaitFailed, identifier, status)
+		}
+
+		next := bctx.NextBackOff()
+		if next == backoff.Stop {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("rds: gave up waiting for %s, last status %s", identifier, status)
+		}
+
+		if err := aws.SleepWithContext(ctx, next); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package rds
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	goctx "context"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// statusSequence poll returning statuses in order, repeating the last one
+func statusSequence(statuses ...string) waitPoll {
+	i := 0
+	return func(goctx.Context) (string, bool, error) {
+		status := statuses[i]
+		if i < len(statuses)-1 {
+			i++
+		}
+		return status, status == "available", nil
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	var seen []string
+	opts := &WaitOpts{
+		Backoff:  backoff.NewConstantBackOff(time.Millisecond),
+		OnStatus: func(status *WaitStatus) { seen = append(seen, status.Status) },
+	}
+
+	err := waitFor(goctx.Background(), "woodstock-qa", opts, statusSequence(statusNotFound, "creating", "backing-up", "available"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{statusNotFound, "creating", "backing-up", "available"}, seen)
+
+	err = waitFor(goctx.Background(), "woodstock-qa", opts, statusSequence("creating", "incompatible-restore"))
+	assert.True(t, errors.Is(err, ErrWaitFailed))
+
+	pollErr := errors.New("throttled")
+	err = waitFor(goctx.Background(), "woodstock-qa", opts, func(goctx.Context) (string, bool, error) { return "", false, pollErr })
+	assert.Equal(t, pollErr, err)
+}
+
+func TestWaitForStops(t *testing.T) {
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := waitFor(ctx, "woodstock-qa", &WaitOpts{Backoff: backoff.NewConstantBackOff(time.Millisecond)}, statusSequence("creating"))
+	assert.True(t, errors.Is(err, goctx.DeadlineExceeded))
+
+	err = waitFor(goctx.Background(), "woodstock-qa", &WaitOpts{Backoff: backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Millisecond), 2)}, statusSequence("creating"))
+	assert.ErrorContains(t, err, "last status creating")
+}
@@ -0,0 +1,201 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	secretsmanagerv2 "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// BatchGetSecretValueChunkSize BatchGetSecretValue accepts at most 20 secret ids per call
+const BatchGetSecretValueChunkSize = 20
+
+// BatchGetSecretValueOptions batch get secret values options
+type BatchGetSecretValueOptions struct {
+	// SecretIDs ARNs or names, any number, requested in chunks of BatchGetSecretValueChunkSize
+	SecretIDs []string
+	// Timeout per BatchGetSecretValue call
+	Timeout time.Duration
+}
+
+// SecretValue secret value returned by a batch get
+type SecretValue struct {
+	SecretVersion
+	SecretString string
+	SecretBinary []byte
+}
+
+// SecretError secret which could not be retrieved in a batch get
+type SecretError struct {
+	SecretID  string
+	ErrorCode string
+	Message   string
+}
+
+// BatchGetSecretValueResponse batch get secret values response
+type BatchGetSecretValueResponse struct {
+	Secrets []*SecretValue
+	// Errors secrets which could not be retrieved, the others are still returned
+	Errors []*SecretError
+	Error  error
+}
+
+// LoadSecretsOptions load secrets under a prefix options
+type LoadSecretsOptions struct {
+	// NamePrefix path like name prefix such as "prod/payments/"
+	NamePrefix string
+	// Tag optional tag filter, see ListSecretsOptions
+	Tag     map[string]string
+	Timeout time.Duration
+}
+
+// LoadSecretsResponse load secrets under a prefix response
+type LoadSecretsResponse struct {
+	// Names secrets merged, in merge order
+	Names []string
+	Error error
+}
+
+// Decode decodes a JSON secret value into out
+func (v *SecretValue) Decode(out interface{}) error {
+	if v.SecretBinary != nil && v.SecretString == "" {
+		return json.Unmarshal(v.SecretBinary, out)
+	}
+
+	return json.Unmarshal([]byte(v.SecretString), out)
+}
+
+// Error implements error
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("secretsmanager: %s: %s: %s", e.SecretID, e.ErrorCode, e.Message)
+}
+
+// BatchGetSecretValue gets the current value of many secrets with as few calls as possible
+func (s *Service) BatchGetSecretValue(opts *BatchGetSecretValueOptions) (resp *BatchGetSecretValueResponse) {
+	resp = new(BatchGetSecretValueResponse)
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	for _, chunk := range chunkSecretIDs(opts.SecretIDs, BatchGetSecretValueChunkSize) {
+		paginator := secretsmanagerv2.NewBatchGetSecretValuePaginator(client, &secretsmanagerv2.BatchGetSecretValueInput{
+			SecretIdList: chunk,
+		})
+
+		for paginator.HasMorePages() {
+			ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+			page, err := paginator.NextPage(ctx)
+			cancel()
+
+			if err != nil {
+				resp.Error = err
+				return
+			}
+
+			for _, entry := range page.SecretValues {
+				resp.Secrets = append(resp.Secrets, &SecretValue{
+					SecretVersion: SecretVersion{
+						ARN:           aws.StringValue(entry.ARN),
+						Name:          aws.StringValue(entry.Name),
+						VersionID:     aws.StringValue(entry.VersionId),
+						VersionStages: entry.VersionStages,
+						CreatedDate:   aws.TimeValue(entry.CreatedDate),
+					},
+					SecretString: aws.StringValue(entry.SecretString),
+					SecretBinary: entry.SecretBinary,
+				})
+			}
+
+			for _, e := range page.Errors {
+				resp.Errors = append(resp.Errors, &SecretError{
+					SecretID:  aws.StringValue(e.SecretId),
+					ErrorCode: aws.StringValue(e.ErrorCode),
+					Message:   aws.StringValue(e.Message),
+				})
+			}
+		}
+	}
+
+	return
+}
+
+// LoadSecrets decodes every JSON secret under a name prefix into v, one merged config struct.
+// Secrets are merged in name order, so "prod/payments/stripe" overrides keys of "prod/payments/common"
+func (s *Service) LoadSecrets(opts *LoadSecretsOptions, v interface{}) (resp *LoadSecretsResponse) {
+	resp = new(LoadSecretsResponse)
+
+	list := s.ListSecrets(&ListSecretsOptions{
+		NamePrefix: opts.NamePrefix,
+		Tag:        opts.Tag,
+		Timeout:    opts.Timeout,
+	})
+	if list.Error != nil {
+		resp.Error = list.Error
+		return
+	}
+
+	if len(list.Secrets) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(list.Secrets))
+	for _, secret := range list.Secrets {
+		ids = append(ids, secret.ARN)
+	}
+
+	batch := s.BatchGetSecretValue(&BatchGetSecretValueOptions{SecretIDs: ids, Timeout: opts.Timeout})
+	if batch.Error != nil {
+		resp.Error = batch.Error
+		return
+	}
+
+	if len(batch.Errors) > 0 {
+		resp.Error = batch.Errors[0]
+		return
+	}
+
+	resp.Names, resp.Error = mergeSecrets(batch.Secrets, v)
+	return
+}
+
+// mergeSecrets decodes secrets into v in name order
+func mergeSecrets(secrets []*SecretValue, v interface{}) ([]string, error) {
+	sorted := append([]*SecretValue(nil), secrets...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	names := make([]string, 0, len(sorted))
+	for _, secret := range sorted {
+		if err := secret.Decode(v); err != nil {
+			return names, fmt.Errorf("secretsmanager: decode %s: %w", secret.Name, err)
+		}
+		names = append(names, secret.Name)
+	}
+
+	return names, nil
+}
+
+// chunkSecretIDs splits ids into chunks of at most size
+func chunkSecretIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+
+	return chunks
+}
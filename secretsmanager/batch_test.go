@@ -0,0 +1,44 @@
+package secretsmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSecrets(t *testing.T) {
+	type config struct {
+		Host   string `json:"host"`
+		Port   int    `json:"port"`
+		APIKey string `json:"api_key"`
+		Debug  bool   `json:"debug"`
+	}
+
+	var cfg config
+	names, err := mergeSecrets([]*SecretValue{
+		{SecretVersion: SecretVersion{Name: "prod/payments/stripe"}, SecretString: `{"api_key":"sk_live","host":"api.stripe.com"}`},
+		{SecretVersion: SecretVersion{Name: "prod/payments/common"}, SecretString: `{"host":"db.local","port":5432,"debug":true}`},
+	}, &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prod/payments/common", "prod/payments/stripe"}, names)
+	assert.Equal(t, config{Host: "api.stripe.com", Port: 5432, APIKey: "sk_live", Debug: true}, cfg)
+
+	_, err = mergeSecrets([]*SecretValue{{SecretVersion: SecretVersion{Name: "prod/payments/plain"}, SecretString: "hunter2"}}, &cfg)
+	assert.ErrorContains(t, err, "prod/payments/plain")
+}
+
+func TestChunkSecretIDs(t *testing.T) {
+	ids := make([]string, 45)
+	chunks := chunkSecretIDs(ids, BatchGetSecretValueChunkSize)
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[2], 5)
+	assert.Empty(t, chunkSecretIDs(nil, BatchGetSecretValueChunkSize))
+}
+
+func TestSecretSummaryMatches(t *testing.T) {
+	summary := &SecretSummary{Name: "prod/payments/stripe", Tag: map[string]string{"env": "prod", "team": "payments"}}
+	assert.True(t, summary.matches(&ListSecretsOptions{NamePrefix: "prod/payments/", Tag: map[string]string{"env": "prod"}}))
+	assert.False(t, summary.matches(&ListSecretsOptions{NamePrefix: "prod/search/"}))
+	// tag-key and tag-value filters would match env=payments on the server
+	assert.False(t, summary.matches(&ListSecretsOptions{Tag: map[string]string{"env": "payments"}}))
+}
@@ -0,0 +1,251 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// DefaultCacheTTL cached secret lifetime when no TTL is configured
+const DefaultCacheTTL = 5 * time.Minute
+
+// CacheOptions secret cache options
+type CacheOptions struct {
+	// TTL default lifetime of a cached secret, defaults to DefaultCacheTTL
+	TTL time.Duration
+	// SecretTTL per secret lifetime overrides, keyed by the secret id used to read it
+	SecretTTL map[string]time.Duration
+	// RefreshWindow how long before expiry a read triggers a background refresh, defaults to a tenth of the TTL
+	RefreshWindow time.Duration
+	// OnRefreshError called when a refresh fails and the stale value is served instead
+	OnRefreshError func(secretID string, err error)
+}
+
+// Cache caches secret values in memory, refreshing them in the background before they expire
+type Cache struct {
+	opts    *CacheOptions
+	fetch   func(opts *GetSecretValueOptions) (*secretsmanager.GetSecretValueOutput, error)
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	calls   map[string]*cacheCall
+}
+
+// cacheEntry cached secret value
+type cacheEntry struct {
+	output     *secretsmanager.GetSecretValueOutput
+	expires    time.Time
+	refreshing bool
+}
+
+// cacheCall in flight fetch shared by concurrent reads of the same secret
+type cacheCall struct {
+	wg     sync.WaitGroup
+	output *secretsmanager.GetSecretValueOutput
+	err    error
+}
+
+// NewCache secret cache initializer
+func NewCache(s *Service, opts *CacheOptions) *Cache {
+	if opts == nil {
+		opts = new(CacheOptions)
+	}
+
+	return &Cache{
+		opts:    opts,
+		fetch:   s.getSecretValue,
+		now:     time.Now,
+		entries: map[string]*cacheEntry{},
+		calls:   map[string]*cacheCall{},
+	}
+}
+
+// GetSecretString gets the plaintext secret string through the cache
+func (c *Cache) GetSecretString(opts *GetSecretValueOptions) (resp *GetSecretStringResponse) {
+	resp = new(GetSecretStringResponse)
+
+	output, err := c.get(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SecretVersion = toSecretVersion(output)
+	resp.Value = string(secretBytes(output))
+	return
+}
+
+// GetSecretBinary gets the secret bytes through the cache
+func (c *Cache) GetSecretBinary(opts *GetSecretValueOptions) (resp *GetSecretBinaryResponse) {
+	resp = new(GetSecretBinaryResponse)
+
+	output, err := c.get(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SecretVersion = toSecretVersion(output)
+	resp.Value = append([]byte(nil), secretBytes(output)...)
+	return
+}
+
+// DecodeSecretValue decodes a JSON secret into v through the cache
+func (c *Cache) DecodeSecretValue(opts *GetSecretValueOptions, v interface{}) (resp *DecodeSecretValueResponse) {
+	resp = new(DecodeSecretValueResponse)
+
+	output, err := c.get(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SecretVersion = toSecretVersion(output)
+	resp.Error = json.Unmarshal(secretBytes(output), v)
+	return
+}
+
+// Invalidate drops every cached version of a secret, matched by the id it was read with, its name or its ARN,
+// so the next read fetches it again. Call it from rotation event handlers
+func (c *Cache) Invalidate(secretID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if cacheKeySecretID(key) == secretID || aws.StringValue(entry.output.Name) == secretID || aws.StringValue(entry.output.ARN) == secretID {
+			delete(c.entries, key)
+			delete(c.calls, key)
+		}
+	}
+
+	for key := range c.calls {
+		if cacheKeySecretID(key) == secretID {
+			delete(c.calls, key)
+		}
+	}
+}
+
+// InvalidateAll drops every cached secret
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]*cacheEntry{}
+	c.calls = map[string]*cacheCall{}
+}
+
+// get reads a secret from the cache, fetching it when missing or expired and serving the stale value if that fails
+func (c *Cache) get(opts *GetSecretValueOptions) (*secretsmanager.GetSecretValueOutput, error) {
+	key := cacheKey(opts)
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && now.Before(entry.expires) {
+		if !entry.refreshing && !now.Before(entry.expires.Add(-c.refreshWindow(opts.SecretID))) {
+			entry.refreshing = true
+			go c.refresh(key, opts)
+		}
+		c.mu.Unlock()
+		return entry.output, nil
+	}
+	c.mu.Unlock()
+
+	output, err := c.load(key, opts)
+	if err != nil && ok {
+		c.refreshFailed(opts.SecretID, err)
+		return entry.output, nil
+	}
+
+	return output, err
+}
+
+// refresh reloads a secret in the background, keeping the current value on failure
+func (c *Cache) refresh(key string, opts *GetSecretValueOptions) {
+	if _, err := c.load(key, opts); err != nil {
+		c.mu.Lock()
+		if entry, ok := c.entries[key]; ok {
+			entry.refreshing = false
+		}
+		c.mu.Unlock()
+
+		c.refreshFailed(opts.SecretID, err)
+	}
+}
+
+// load fetches a secret once for all concurrent callers and stores it
+func (c *Cache) load(key string, opts *GetSecretValueOptions) (*secretsmanager.GetSecretValueOutput, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		call.wg.Wait()
+		return call.output, call.err
+	}
+
+	call := new(cacheCall)
+	call.wg.Add(1)
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.output, call.err = c.fetch(opts)
+
+	c.mu.Lock()
+	// an invalidation while fetching discards the result, it may predate the rotation
+	if c.calls[key] == call {
+		delete(c.calls, key)
+		if call.err == nil {
+			c.entries[key] = &cacheEntry{
+				output:  call.output,
+				expires: c.now().Add(c.ttl(opts.SecretID)),
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	call.wg.Done()
+	return call.output, call.err
+}
+
+// ttl lifetime of a secret
+func (c *Cache) ttl(secretID string) time.Duration {
+	if ttl, ok := c.opts.SecretTTL[secretID]; ok && ttl > 0 {
+		return ttl
+	}
+
+	if c.opts.TTL > 0 {
+		return c.opts.TTL
+	}
+
+	return DefaultCacheTTL
+}
+
+// refreshWindow how long before expiry a secret is refreshed
+func (c *Cache) refreshWindow(secretID string) time.Duration {
+	if c.opts.RefreshWindow > 0 {
+		return c.opts.RefreshWindow
+	}
+
+	return c.ttl(secretID) / 10
+}
+
+// refreshFailed reports a failed refresh
+func (c *Cache) refreshFailed(secretID string, err error) {
+	if c.opts.OnRefreshError != nil {
+		c.opts.OnRefreshError(secretID, err)
+	}
+}
+
+// cacheKey identifies a secret version selection
+func cacheKey(opts *GetSecretValueOptions) string {
+	return opts.SecretID + "\x00" + opts.VersionID + "\x00" + opts.VersionStage
+}
+
+// cacheKeySecretID secret id of a cache key
+func cacheKeySecretID(key string) string {
+	id, _, _ := strings.Cut(key, "\x00")
+	return id
+}
@@ -0,0 +1,128 @@
+package secretsmanager
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSecrets counts fetches and serves a value that changes with every fetch
+type fakeSecrets struct {
+	calls int32
+	err   atomic.Value
+	delay time.Duration
+}
+
+func (f *fakeSecrets) fetch(opts *GetSecretValueOptions) (*secretsmanager.GetSecretValueOutput, error) {
+	n := atomic.AddInt32(&f.calls, 1)
+	time.Sleep(f.delay)
+	if err, ok := f.err.Load().(error); ok && err != nil {
+		return nil, err
+	}
+
+	return &secretsmanager.GetSecretValueOutput{
+		ARN:          aws.String("arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:" + opts.SecretID + "-AbCdEf"),
+		Name:         aws.String(opts.SecretID),
+		SecretString: aws.String(string(rune('0' + n))),
+	}, nil
+}
+
+func newTestCache(f *fakeSecrets, now *time.Time, opts *CacheOptions) *Cache {
+	c := NewCache(NewService("", ""), opts)
+	c.fetch = f.fetch
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestCacheTTL(t *testing.T) {
+	f := new(fakeSecrets)
+	now := time.Now()
+	c := newTestCache(f, &now, &CacheOptions{TTL: time.Minute, SecretTTL: map[string]time.Duration{"short": time.Second}})
+	opts := &GetSecretValueOptions{SecretID: "db"}
+
+	assert.Equal(t, "1", c.GetSecretString(opts).Value)
+	assert.Equal(t, "1", c.GetSecretString(opts).Value)
+	assert.EqualValues(t, 1, f.calls)
+
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "2", c.GetSecretString(opts).Value)
+
+	short := &GetSecretValueOptions{SecretID: "short"}
+	assert.Equal(t, "3", c.GetSecretString(short).Value)
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, "4", c.GetSecretString(short).Value)
+}
+
+func TestCacheStaleOnError(t *testing.T) {
+	f := new(fakeSecrets)
+	now := time.Now()
+	var refreshErr error
+	c := newTestCache(f, &now, &CacheOptions{TTL: time.Minute, OnRefreshError: func(secretID string, err error) { refreshErr = err }})
+	opts := &GetSecretValueOptions{SecretID: "db"}
+
+	assert.Equal(t, "1", c.GetSecretString(opts).Value)
+
+	f.err.Store(errors.New("throttled"))
+	now = now.Add(2 * time.Minute)
+	resp := c.GetSecretString(opts)
+	assert.NoError(t, resp.Error)
+	assert.Equal(t, "1", resp.Value)
+	assert.EqualError(t, refreshErr, "throttled")
+
+	// nothing to fall back to
+	assert.EqualError(t, c.GetSecretString(&GetSecretValueOptions{SecretID: "other"}).Error, "throttled")
+}
+
+func TestCacheBackgroundRefresh(t *testing.T) {
+	f := new(fakeSecrets)
+	now := time.Now()
+	c := newTestCache(f, &now, &CacheOptions{TTL: time.Minute, RefreshWindow: 10 * time.Second})
+	opts := &GetSecretValueOptions{SecretID: "db"}
+
+	assert.Equal(t, "1", c.GetSecretString(opts).Value)
+
+	// inside the refresh window the cached value is served while refreshing
+	now = now.Add(55 * time.Second)
+	assert.Equal(t, "1", c.GetSecretString(opts).Value)
+	assert.Eventually(t, func() bool {
+		return c.GetSecretString(opts).Value == "2"
+	}, time.Second, 5*time.Millisecond)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&f.calls))
+}
+
+func TestCacheSingleflight(t *testing.T) {
+	f := &fakeSecrets{delay: 50 * time.Millisecond}
+	now := time.Now()
+	c := newTestCache(f, &now, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "1", c.GetSecretString(&GetSecretValueOptions{SecretID: "db"}).Value)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, f.calls)
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	f := new(fakeSecrets)
+	now := time.Now()
+	c := newTestCache(f, &now, nil)
+	opts := &GetSecretValueOptions{SecretID: "db"}
+
+	assert.Equal(t, "1", c.GetSecretString(opts).Value)
+	c.Invalidate("arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:db-AbCdEf")
+	assert.Equal(t, "2", c.GetSecretString(opts).Value)
+
+	c.InvalidateAll()
+	assert.Equal(t, "3", c.GetSecretString(opts).Value)
+}
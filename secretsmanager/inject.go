@@ -0,0 +1,322 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMissingSecrets some tagged fields could not be resolved, the error lists all of them
+var ErrMissingSecrets = errors.New("secretsmanager: missing secrets")
+
+// InjectSecretsOptions inject secrets options
+type InjectSecretsOptions struct {
+	// EnvFallback resolves fields missing from Secrets Manager, or all fields when it cannot be reached,
+	// from environment variables named by the env tag, or derived from the reference: prod/db#password reads PROD_DB_PASSWORD.
+	// Fields of secrets which failed to be retrieved, such as on access denied, never fall back
+	EnvFallback bool
+	Timeout     time.Duration
+}
+
+// InjectSecretsResponse inject secrets response
+type InjectSecretsResponse struct {
+	// Missing references of fields which could not be resolved, including those of secrets which failed to be retrieved
+	Missing []string
+	Error   error
+}
+
+// secretField struct field tagged with a secret reference
+type secretField struct {
+	path     string
+	ref      string
+	secretID string
+	key      string
+	env      string
+	value    reflect.Value
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// InjectSecrets fills the fields of the struct pointed to by v tagged `secret:"<secret id>#<json key>"`,
+// or `secret:"<secret id>"` for the whole secret string. Values are converted to the field type:
+// strings, bools, ints, uints, floats, time.Duration and slices of those, from JSON arrays or comma separated strings.
+// Nested structs are walked, every missing key is reported at once
+func (s *Service) InjectSecrets(opts *InjectSecretsOptions, v interface{}) (resp *InjectSecretsResponse) {
+	resp = new(InjectSecretsResponse)
+
+	var fields []*secretField
+	if err := collectSecretFields(v, &fields); err != nil {
+		resp.Error = err
+		return
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, field := range fields {
+		if !seen[field.secretID] {
+			seen[field.secretID] = true
+			ids = append(ids, field.secretID)
+		}
+	}
+
+	secrets := map[string]string{}
+	var failed []*SecretError
+	var fetchErr error
+	if len(ids) > 0 {
+		batch := s.BatchGetSecretValue(&BatchGetSecretValueOptions{SecretIDs: ids, Timeout: opts.Timeout})
+		if batch.Error != nil && !opts.EnvFallback {
+			resp.Error = batch.Error
+			return
+		}
+		fetchErr = batch.Error
+		failed = batch.Errors
+
+		for _, secret := range batch.Secrets {
+			value := secret.SecretString
+			if value == "" && secret.SecretBinary != nil {
+				value = string(secret.SecretBinary)
+			}
+			secrets[secret.Name] = value
+			secrets[secret.ARN] = value
+		}
+	}
+
+	var env func(string) (string, bool)
+	if opts.EnvFallback {
+		env = os.LookupEnv
+	}
+
+	resp.Missing, resp.Error = injectSecrets(fields, secrets, failed, env)
+	if resp.Error != nil && fetchErr != nil {
+		resp.Error = errors.Join(resp.Error, fetchErr)
+	}
+
+	return
+}
+
+// collectSecretFields finds the tagged fields of the struct pointed to by v
+func collectSecretFields(v interface{}, fields *[]*secretField) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("secretsmanager: inject target must be a non-nil struct pointer, got %T", v)
+	}
+
+	return walkSecretFields(rv.Elem(), "", fields)
+}
+
+// walkSecretFields collects tagged fields of a struct value, recursing into untagged nested structs
+func walkSecretFields(rv reflect.Value, prefix string, fields *[]*secretField) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		path := prefix + sf.Name
+		ref, ok := sf.Tag.Lookup("secret")
+		if !ok {
+			if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeOf(time.Time{}) {
+				if err := walkSecretFields(rv.Field(i), path+".", fields); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		id, key, _ := strings.Cut(ref, "#")
+		if id == "" {
+			return fmt.Errorf("secretsmanager: field %s has an empty secret id", path)
+		}
+
+		env := sf.Tag.Get("env")
+		if env == "" {
+			env = envName(ref)
+		}
+
+		*fields = append(*fields, &secretField{
+			path:     path,
+			ref:      ref,
+			secretID: id,
+			key:      key,
+			env:      env,
+			value:    rv.Field(i),
+		})
+	}
+
+	return nil
+}
+
+// injectSecrets sets every field from secrets, keyed by secret id, falling back to env when given.
+// Fields of failed secrets are reported missing without falling back, along with the error of each failed secret
+func injectSecrets(fields []*secretField, secrets map[string]string, failed []*SecretError, env func(string) (string, bool)) ([]string, error) {
+	decoded := map[string]map[string]interface{}{}
+	failedIDs := map[string]bool{}
+	for _, secretErr := range failed {
+		failedIDs[secretErr.SecretID] = true
+	}
+
+	var missing, unresolved []string
+	var errs []error
+
+	for _, field := range fields {
+		if failedIDs[field.secretID] {
+			missing = append(missing, field.ref)
+			continue
+		}
+
+		raw, ok := lookupSecret(field, secrets, decoded)
+		if !ok && env != nil {
+			raw, ok = env(field.env)
+		}
+
+		if !ok {
+			missing = append(missing, field.ref)
+			unresolved = append(unresolved, field.ref)
+			continue
+		}
+
+		if err := setSecretField(field.value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("secretsmanager: field %s (%s): %w", field.path, field.ref, err))
+		}
+	}
+
+	var head []error
+	if len(unresolved) > 0 {
+		head = append(head, fmt.Errorf("%w: %s", ErrMissingSecrets, strings.Join(unresolved, ", ")))
+	}
+	for _, secretErr := range failed {
+		head = append(head, secretErr)
+	}
+
+	return missing, errors.Join(append(head, errs...)...)
+}
+
+// lookupSecret raw value of a field, the JSON value of its key or the whole secret string
+func lookupSecret(field *secretField, secrets map[string]string, decoded map[string]map[string]interface{}) (interface{}, bool) {
+	secret, ok := secrets[field.secretID]
+	if !ok {
+		return nil, false
+	}
+
+	if field.key == "" {
+		return secret, true
+	}
+
+	values, ok := decoded[field.secretID]
+	if !ok {
+		values = map[string]interface{}{}
+		// plaintext secrets have no keys, their keyed fields are reported missing
+		_ = json.Unmarshal([]byte(secret), &values)
+		decoded[field.secretID] = values
+	}
+
+	value, ok := values[field.key]
+	if !ok || value == nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// setSecretField converts a JSON or string value to the field type
+func setSecretField(field reflect.Value, raw interface{}) error {
+	if field.Kind() == reflect.Slice {
+		var items []interface{}
+		switch r := raw.(type) {
+		case []interface{}:
+			items = r
+		case string:
+			for _, item := range strings.Split(r, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		default:
+			items = []interface{}{r}
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setSecretField(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+		return nil
+	}
+
+	var s string
+	switch r := raw.(type) {
+	case string:
+		s = r
+	case float64:
+		s = strconv.FormatFloat(r, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(r)
+	default:
+		return fmt.Errorf("cannot convert %T to %s", raw, field.Type())
+	}
+
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// envName environment variable name of a secret reference
+func envName(ref string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, ref)
+}
@@ -0,0 +1,102 @@
+package secretsmanager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type injectConfig struct {
+	DB struct {
+		Host     string        `secret:"prod/db#host"`
+		Port     int           `secret:"prod/db#port"`
+		Password string        `secret:"prod/db#password"`
+		Timeout  time.Duration `secret:"prod/db#timeout"`
+	}
+	Debug   bool     `secret:"prod/app#debug"`
+	Origins []string `secret:"prod/app#origins"`
+	Hosts   []string `secret:"prod/app#hosts"`
+	Token   string   `secret:"prod/token"`
+	APIKey  string   `secret:"prod/app#api_key" env:"APP_API_KEY"`
+	Ratio   float64  `secret:"prod/app#ratio"`
+	ignored string
+}
+
+func TestInjectSecrets(t *testing.T) {
+	var cfg injectConfig
+	var fields []*secretField
+	assert.NoError(t, collectSecretFields(&cfg, &fields))
+	assert.Len(t, fields, 10)
+
+	missing, err := injectSecrets(fields, map[string]string{
+		"prod/db":    `{"host":"db.local","port":5432,"password":"hunter2","timeout":"5s"}`,
+		"prod/app":   `{"debug":true,"origins":["https://woodstock.club","https://app.woodstock.club"],"hosts":"a.local, b.local","api_key":"key","ratio":0.25}`,
+		"prod/token": "plaintext-token",
+	}, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+
+	assert.Equal(t, "db.local", cfg.DB.Host)
+	assert.Equal(t, 5432, cfg.DB.Port)
+	assert.Equal(t, "hunter2", cfg.DB.Password)
+	assert.Equal(t, 5*time.Second, cfg.DB.Timeout)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"https://woodstock.club", "https://app.woodstock.club"}, cfg.Origins)
+	assert.Equal(t, []string{"a.local", "b.local"}, cfg.Hosts)
+	assert.Equal(t, "plaintext-token", cfg.Token)
+	assert.Equal(t, "key", cfg.APIKey)
+	assert.Equal(t, 0.25, cfg.Ratio)
+}
+
+func TestInjectSecretsMissing(t *testing.T) {
+	var cfg injectConfig
+	var fields []*secretField
+	assert.NoError(t, collectSecretFields(&cfg, &fields))
+
+	env := map[string]string{"PROD_DB_PASSWORD": "from-env", "APP_API_KEY": "env-key", "PROD_APP_DEBUG": "yes"}
+	missing, err := injectSecrets(fields, map[string]string{
+		"prod/db": `{"host":"db.local","port":"not a port"}`,
+	}, nil, func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	})
+
+	assert.True(t, errors.Is(err, ErrMissingSecrets))
+	assert.Equal(t, []string{"prod/db#timeout", "prod/app#origins", "prod/app#hosts", "prod/token", "prod/app#ratio"}, missing)
+	assert.ErrorContains(t, err, "DB.Port")
+	assert.ErrorContains(t, err, "Debug")
+	assert.Equal(t, "from-env", cfg.DB.Password)
+	assert.Equal(t, "env-key", cfg.APIKey)
+}
+
+func TestInjectSecretsPartialFailure(t *testing.T) {
+	var cfg injectConfig
+	var fields []*secretField
+	assert.NoError(t, collectSecretFields(&cfg, &fields))
+
+	denied := &SecretError{SecretID: "prod/app", ErrorCode: "AccessDeniedException", Message: "not authorized"}
+	env := map[string]string{"PROD_APP_DEBUG": "true", "APP_API_KEY": "env-key", "PROD_TOKEN": "env-token"}
+	missing, err := injectSecrets(fields, map[string]string{
+		"prod/db": `{"host":"db.local","port":5432,"password":"hunter2","timeout":"5s"}`,
+	}, []*SecretError{denied}, func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	})
+
+	// fields of the failed secret do not fall back to env, the others still do
+	assert.ErrorIs(t, err, denied)
+	assert.False(t, errors.Is(err, ErrMissingSecrets))
+	assert.Equal(t, []string{"prod/app#debug", "prod/app#origins", "prod/app#hosts", "prod/app#api_key", "prod/app#ratio"}, missing)
+	assert.False(t, cfg.Debug)
+	assert.Empty(t, cfg.APIKey)
+	assert.Equal(t, "db.local", cfg.DB.Host)
+	assert.Equal(t, "env-token", cfg.Token)
+}
+
+func TestInjectSecretsTarget(t *testing.T) {
+	var fields []*secretField
+	assert.Error(t, collectSecretFields(injectConfig{}, &fields))
+	assert.Equal(t, "PROD_DB_PASSWORD", envName("prod/db#password"))
+}
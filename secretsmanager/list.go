@@ -0,0 +1,187 @@
+package secretsmanager
+
+import (
+	"strings"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// ListSecretsOptions list secrets options, zero filters match all secrets
+type ListSecretsOptions struct {
+	// NamePrefix path like name prefix such as "prod/payments/"
+	NamePrefix string
+	// Tag secrets must carry every key with exactly the given value
+	Tag map[string]string
+	// Timeout per page
+	Timeout time.Duration
+}
+
+// SecretSummary secret metadata returned by listing, without the secret value
+type SecretSummary struct {
+	ARN             string
+	Name            string
+	Description     string
+	KmsKeyID        string
+	Tag             map[string]string
+	CreatedDate     time.Time
+	LastChangedDate time.Time
+	DeletedDate     time.Time
+}
+
+// ListSecretsResponse list secrets response
+type ListSecretsResponse struct {
+	Secrets []*SecretSummary
+	Error   error
+}
+
+// ListSecretsIterator iterates secrets page by page
+type ListSecretsIterator struct {
+	service   *Service
+	opts      *ListSecretsOptions
+	page      []*secretsmanager.SecretListEntry
+	current   *SecretSummary
+	nextToken *string
+	started   bool
+	err       error
+}
+
+// ListSecrets lists every secret matching the filters
+func (s *Service) ListSecrets(opts *ListSecretsOptions) (resp *ListSecretsResponse) {
+	resp = new(ListSecretsResponse)
+
+	it := s.ListSecretsIterator(opts)
+	for it.Next() {
+		resp.Secrets = append(resp.Secrets, it.Secret())
+	}
+
+	resp.Error = it.Err()
+	return
+}
+
+// ListSecretsIterator returns an iterator walking the secrets matching the filters
+func (s *Service) ListSecretsIterator(opts *ListSecretsOptions) *ListSecretsIterator {
+	return &ListSecretsIterator{
+		service: s,
+		opts:    opts,
+	}
+}
+
+// Next advances to the next matching secret, fetching the next page when needed
+func (it *ListSecretsIterator) Next() bool {
+	for {
+		for len(it.page) == 0 {
+			if it.err != nil || (it.started && it.nextToken == nil) {
+				it.current = nil
+				return false
+			}
+
+			it.fetch()
+		}
+
+		entry := it.page[0]
+		it.page = it.page[1:]
+
+		// the tag-key and tag-value filters match independently, so key/value pairs are checked here
+		summary := toSecretSummary(entry)
+		if summary.matches(it.opts) {
+			it.current = summary
+			return true
+		}
+	}
+}
+
+// Secret current secret
+func (it *ListSecretsIterator) Secret() *SecretSummary {
+	return it.current
+}
+
+// Err error occurred while fetching pages, if any
+func (it *ListSecretsIterator) Err() error {
+	return it.err
+}
+
+// fetch loads the next page of secrets
+func (it *ListSecretsIterator) fetch() {
+	t := 30 * time.Second
+	if it.opts.Timeout > 0 {
+		t = it.opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := it.service.client().ListSecretsWithContext(ctx, &secretsmanager.ListSecretsInput{
+		Filters:   listSecretsFilters(it.opts),
+		NextToken: it.nextToken,
+	})
+
+	it.started = true
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = output.SecretList
+	it.nextToken = output.NextToken
+	if aws.StringValue(it.nextToken) == "" {
+		it.nextToken = nil
+	}
+}
+
+// matches whether the secret passes the name prefix and tag filters
+func (summary *SecretSummary) matches(opts *ListSecretsOptions) bool {
+	if !strings.HasPrefix(summary.Name, opts.NamePrefix) {
+		return false
+	}
+
+	for k, v := range opts.Tag {
+		if value, ok := summary.Tag[k]; !ok || value != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// listSecretsFilters server side filters narrowing the listing
+func listSecretsFilters(opts *ListSecretsOptions) []*secretsmanager.Filter {
+	var filters []*secretsmanager.Filter
+	if opts.NamePrefix != "" {
+		filters = append(filters, &secretsmanager.Filter{
+			Key:    aws.String(secretsmanager.FilterNameStringTypeName),
+			Values: aws.StringSlice([]string{opts.NamePrefix}),
+		})
+	}
+
+	for k, v := range opts.Tag {
+		filters = append(filters,
+			&secretsmanager.Filter{Key: aws.String(secretsmanager.FilterNameStringTypeTagKey), Values: aws.StringSlice([]string{k})},
+			&secretsmanager.Filter{Key: aws.String(secretsmanager.FilterNameStringTypeTagValue), Values: aws.StringSlice([]string{v})},
+		)
+	}
+
+	return filters
+}
+
+// toSecretSummary secret summary of a list entry
+func toSecretSummary(entry *secretsmanager.SecretListEntry) *SecretSummary {
+	summary := &SecretSummary{
+		ARN:             aws.StringValue(entry.ARN),
+		Name:            aws.StringValue(entry.Name),
+		Description:     aws.StringValue(entry.Description),
+		KmsKeyID:        aws.StringValue(entry.KmsKeyId),
+		Tag:             map[string]string{},
+		CreatedDate:     aws.TimeValue(entry.CreatedDate),
+		LastChangedDate: aws.TimeValue(entry.LastChangedDate),
+		DeletedDate:     aws.TimeValue(entry.DeletedDate),
+	}
+
+	for _, tag := range entry.Tags {
+		summary.Tag[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return summary
+}
@@ -0,0 +1,236 @@
+package secretsmanager
+
+import (
+	"errors"
+	"fmt"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// RotationStep step of the rotation state machine
+type RotationStep string
+
+const (
+	RotationStepCreateSecret RotationStep = "createSecret"
+	RotationStepSetSecret    RotationStep = "setSecret"
+	RotationStepTestSecret   RotationStep = "testSecret"
+	RotationStepFinishSecret RotationStep = "finishSecret"
+)
+
+// ErrRotationNotEnabled the secret being rotated does not have rotation enabled
+var ErrRotationNotEnabled = errors.New("secretsmanager: rotation is not enabled")
+
+// RotationEvent event Secrets Manager sends to a rotation Lambda
+type RotationEvent struct {
+	Step               RotationStep `json:"Step"`
+	SecretID           string       `json:"SecretId"`
+	ClientRequestToken string       `json:"ClientRequestToken"`
+}
+
+// RotationStrategy secret specific part of a rotation
+type RotationStrategy interface {
+	// Generate returns the new secret string, derived from the current one, for example with a new password
+	Generate(ctx goctx.Context, current *SecretValue) (string, error)
+	// Set applies the pending secret to the resource, for example with ALTER USER, it may be called again with the same pending secret
+	Set(ctx goctx.Context, current, pending *SecretValue) error
+	// Test checks the pending secret works against the resource
+	Test(ctx goctx.Context, pending *SecretValue) error
+}
+
+// Rotator runs the rotation state machine of a rotation Lambda
+type Rotator struct {
+	api      rotationAPI
+	strategy RotationStrategy
+}
+
+// rotationAPI Secrets Manager calls used during rotation
+type rotationAPI interface {
+	DescribeSecretWithContext(goctx.Context, *secretsmanager.DescribeSecretInput, ...request.Option) (*secretsmanager.DescribeSecretOutput, error)
+	GetSecretValueWithContext(goctx.Context, *secretsmanager.GetSecretValueInput, ...request.Option) (*secretsmanager.GetSecretValueOutput, error)
+	PutSecretValueWithContext(goctx.Context, *secretsmanager.PutSecretValueInput, ...request.Option) (*secretsmanager.PutSecretValueOutput, error)
+	UpdateSecretVersionStageWithContext(goctx.Context, *secretsmanager.UpdateSecretVersionStageInput, ...request.Option) (*secretsmanager.UpdateSecretVersionStageOutput, error)
+}
+
+// NewRotator rotator initializer
+func NewRotator(s *Service, strategy RotationStrategy) *Rotator {
+	return &Rotator{
+		api:      s.client(),
+		strategy: strategy,
+	}
+}
+
+// Handle handles a rotation event, it can be passed to lambda.Start as is.
+// Every step is idempotent, so retried invocations are safe
+func (r *Rotator) Handle(ctx goctx.Context, event RotationEvent) error {
+	describe, err := r.api.DescribeSecretWithContext(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(event.SecretID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !aws.BoolValue(describe.RotationEnabled) {
+		return fmt.Errorf("%w: %s", ErrRotationNotEnabled, event.SecretID)
+	}
+
+	stages, ok := describe.VersionIdsToStages[event.ClientRequestToken]
+	if !ok {
+		return fmt.Errorf("secretsmanager: version %s of %s has no stage for rotation", event.ClientRequestToken, event.SecretID)
+	}
+
+	if hasStage(stages, VersionStageCurrent) {
+		// finishSecret moved AWSCURRENT, a retry still clears AWSPENDING when removing it failed
+		if event.Step == RotationStepFinishSecret && hasStage(stages, VersionStagePending) {
+			return r.removePending(ctx, event)
+		}
+
+		return nil
+	}
+
+	if !hasStage(stages, VersionStagePending) {
+		return fmt.Errorf("secretsmanager: version %s of %s is not %s", event.ClientRequestToken, event.SecretID, VersionStagePending)
+	}
+
+	switch event.Step {
+	case RotationStepCreateSecret:
+		return r.createSecret(ctx, event)
+	case RotationStepSetSecret:
+		return r.setSecret(ctx, event)
+	case RotationStepTestSecret:
+		return r.testSecret(ctx, event)
+	case RotationStepFinishSecret:
+		return r.finishSecret(ctx, event, describe.VersionIdsToStages)
+	default:
+		return fmt.Errorf("secretsmanager: unknown rotation step %q", event.Step)
+	}
+}
+
+// createSecret generates the pending secret unless a previous invocation already stored it
+func (r *Rotator) createSecret(ctx goctx.Context, event RotationEvent) error {
+	current, err := r.getValue(ctx, event.SecretID, "", VersionStageCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getValue(ctx, event.SecretID, event.ClientRequestToken, VersionStagePending)
+	if err == nil {
+		return nil
+	}
+
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) || aerr.Code() != secretsmanager.ErrCodeResourceNotFoundException {
+		return err
+	}
+
+	secret, err := r.strategy.Generate(ctx, current)
+	if err != nil {
+		return err
+	}
+
+	// the token as version id makes a racing duplicate put fail instead of creating a second version
+	_, err = r.api.PutSecretValueWithContext(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:           aws.String(event.SecretID),
+		ClientRequestToken: aws.String(event.ClientRequestToken),
+		SecretString:       aws.String(secret),
+		VersionStages:      aws.StringSlice([]string{VersionStagePending}),
+	})
+
+	return err
+}
+
+// setSecret applies the pending secret to the resource
+func (r *Rotator) setSecret(ctx goctx.Context, event RotationEvent) error {
+	current, err := r.getValue(ctx, event.SecretID, "", VersionStageCurrent)
+	if err != nil {
+		return err
+	}
+
+	pending, err := r.getValue(ctx, event.SecretID, event.ClientRequestToken, VersionStagePending)
+	if err != nil {
+		return err
+	}
+
+	return r.strategy.Set(ctx, current, pending)
+}
+
+// testSecret checks the pending secret
+func (r *Rotator) testSecret(ctx goctx.Context, event RotationEvent) error {
+	pending, err := r.getValue(ctx, event.SecretID, event.ClientRequestToken, VersionStagePending)
+	if err != nil {
+		return err
+	}
+
+	return r.strategy.Test(ctx, pending)
+}
+
+// finishSecret moves AWSCURRENT to the pending version, the previous version becomes AWSPREVIOUS
+func (r *Rotator) finishSecret(ctx goctx.Context, event RotationEvent, versions map[string][]*string) error {
+	input := &secretsmanager.UpdateSecretVersionStageInput{
+		SecretId:        aws.String(event.SecretID),
+		VersionStage:    aws.String(VersionStageCurrent),
+		MoveToVersionId: aws.String(event.ClientRequestToken),
+	}
+
+	for id, stages := range versions {
+		if id != event.ClientRequestToken && hasStage(stages, VersionStageCurrent) {
+			input.RemoveFromVersionId = aws.String(id)
+			break
+		}
+	}
+
+	if _, err := r.api.UpdateSecretVersionStageWithContext(ctx, input); err != nil {
+		return err
+	}
+
+	return r.removePending(ctx, event)
+}
+
+// removePending removes AWSPENDING from the rotated version
+func (r *Rotator) removePending(ctx goctx.Context, event RotationEvent) error {
+	_, err := r.api.UpdateSecretVersionStageWithContext(ctx, &secretsmanager.UpdateSecretVersionStageInput{
+		SecretId:            aws.String(event.SecretID),
+		VersionStage:        aws.String(VersionStagePending),
+		RemoveFromVersionId: aws.String(event.ClientRequestToken),
+	})
+
+	return err
+}
+
+// getValue gets a secret version by id and stage
+func (r *Rotator) getValue(ctx goctx.Context, secretID, versionID, stage string) (*SecretValue, error) {
+	input := &secretsmanager.GetSecretValueInput{
+		SecretId:     aws.String(secretID),
+		VersionStage: aws.String(stage),
+	}
+
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	output, err := r.api.GetSecretValueWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretValue{
+		SecretVersion: toSecretVersion(output),
+		SecretString:  aws.StringValue(output.SecretString),
+		SecretBinary:  output.SecretBinary,
+	}, nil
+}
+
+// hasStage whether stages contains stage
+func hasStage(stages []*string, stage string) bool {
+	for _, s := range stages {
+		if aws.StringValue(s) == stage {
+			return true
+		}
+	}
+
+	return false
+}
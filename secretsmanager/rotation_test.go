@@ -0,0 +1,177 @@
+package secretsmanager
+
+import (
+	"errors"
+	"testing"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRotationAPI in memory secret versions and their stages
+type fakeRotationAPI struct {
+	values map[string]string
+	stages map[string][]string
+	puts   int
+	// updateErrs errors returned once by the next stage update of the given stage
+	updateErrs map[string]error
+}
+
+func (f *fakeRotationAPI) DescribeSecretWithContext(_ goctx.Context, _ *secretsmanager.DescribeSecretInput, _ ...request.Option) (*secretsmanager.DescribeSecretOutput, error) {
+	versions := map[string][]*string{}
+	for id, stages := range f.stages {
+		versions[id] = aws.StringSlice(stages)
+	}
+
+	return &secretsmanager.DescribeSecretOutput{RotationEnabled: aws.Bool(true), VersionIdsToStages: versions}, nil
+}
+
+func (f *fakeRotationAPI) GetSecretValueWithContext(_ goctx.Context, input *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	for id, stages := range f.stages {
+		if input.VersionId != nil && aws.StringValue(input.VersionId) != id {
+			continue
+		}
+
+		// rotation labels the new version AWSPENDING before createSecret stores its value
+		value, ok := f.values[id]
+		if !ok {
+			continue
+		}
+
+		for _, stage := range stages {
+			if stage == aws.StringValue(input.VersionStage) {
+				return &secretsmanager.GetSecretValueOutput{VersionId: aws.String(id), SecretString: aws.String(value)}, nil
+			}
+		}
+	}
+
+	return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+}
+
+func (f *fakeRotationAPI) PutSecretValueWithContext(_ goctx.Context, input *secretsmanager.PutSecretValueInput, _ ...request.Option) (*secretsmanager.PutSecretValueOutput, error) {
+	f.puts++
+	id := aws.StringValue(input.ClientRequestToken)
+	f.values[id] = aws.StringValue(input.SecretString)
+	f.stages[id] = aws.StringValueSlice(input.VersionStages)
+	return new(secretsmanager.PutSecretValueOutput), nil
+}
+
+func (f *fakeRotationAPI) UpdateSecretVersionStageWithContext(_ goctx.Context, input *secretsmanager.UpdateSecretVersionStageInput, _ ...request.Option) (*secretsmanager.UpdateSecretVersionStageOutput, error) {
+	stage := aws.StringValue(input.VersionStage)
+	if err, ok := f.updateErrs[stage]; ok {
+		delete(f.updateErrs, stage)
+		return nil, err
+	}
+
+	if from := aws.StringValue(input.RemoveFromVersionId); from != "" {
+		var kept []string
+		for _, s := range f.stages[from] {
+			if s != stage {
+				kept = append(kept, s)
+			}
+		}
+		if stage == VersionStageCurrent {
+			kept = append(kept, VersionStagePrevious)
+		}
+		f.stages[from] = kept
+	}
+
+	if to := aws.StringValue(input.MoveToVersionId); to != "" {
+		f.stages[to] = append(f.stages[to], stage)
+	}
+
+	return new(secretsmanager.UpdateSecretVersionStageOutput), nil
+}
+
+// recordingStrategy appends a suffix to the password and records its calls
+type recordingStrategy struct {
+	set, tested []string
+	testErr     error
+}
+
+func (r *recordingStrategy) Generate(_ goctx.Context, current *SecretValue) (string, error) {
+	return current.SecretString + "-next", nil
+}
+
+func (r *recordingStrategy) Set(_ goctx.Context, _, pending *SecretValue) error {
+	r.set = append(r.set, pending.SecretString)
+	return nil
+}
+
+func (r *recordingStrategy) Test(_ goctx.Context, pending *SecretValue) error {
+	r.tested = append(r.tested, pending.SecretString)
+	return r.testErr
+}
+
+func TestRotator(t *testing.T) {
+	api := &fakeRotationAPI{
+		values: map[string]string{"v1": "pw"},
+		stages: map[string][]string{"v1": {VersionStageCurrent}, "v2": {VersionStagePending}},
+	}
+	strategy := new(recordingStrategy)
+	r := &Rotator{api: api, strategy: strategy}
+	ctx := goctx.Background()
+	event := func(step RotationStep) RotationEvent {
+		return RotationEvent{Step: step, SecretID: "prod/db", ClientRequestToken: "v2"}
+	}
+
+	// createSecret is retried, the pending secret is generated once
+	assert.NoError(t, r.Handle(ctx, event(RotationStepCreateSecret)))
+	assert.NoError(t, r.Handle(ctx, event(RotationStepCreateSecret)))
+	assert.Equal(t, 1, api.puts)
+	assert.Equal(t, "pw-next", api.values["v2"])
+
+	assert.NoError(t, r.Handle(ctx, event(RotationStepSetSecret)))
+	assert.NoError(t, r.Handle(ctx, event(RotationStepTestSecret)))
+	assert.Equal(t, []string{"pw-next"}, strategy.set)
+	assert.Equal(t, []string{"pw-next"}, strategy.tested)
+
+	assert.NoError(t, r.Handle(ctx, event(RotationStepFinishSecret)))
+	assert.Equal(t, []string{VersionStageCurrent}, api.stages["v2"])
+	assert.Equal(t, []string{VersionStagePrevious}, api.stages["v1"])
+
+	// a retried finishSecret is a no-op
+	assert.NoError(t, r.Handle(ctx, event(RotationStepFinishSecret)))
+	assert.Equal(t, []string{VersionStageCurrent}, api.stages["v2"])
+}
+
+func TestRotatorFinishSecretRetry(t *testing.T) {
+	api := &fakeRotationAPI{
+		values:     map[string]string{"v1": "pw", "v2": "pw-next"},
+		stages:     map[string][]string{"v1": {VersionStageCurrent}, "v2": {VersionStagePending}},
+		updateErrs: map[string]error{VersionStagePending: errors.New("throttled")},
+	}
+	r := &Rotator{api: api, strategy: new(recordingStrategy)}
+	ctx := goctx.Background()
+	event := RotationEvent{Step: RotationStepFinishSecret, SecretID: "prod/db", ClientRequestToken: "v2"}
+
+	// AWSCURRENT moved but removing AWSPENDING failed
+	assert.Error(t, r.Handle(ctx, event))
+	assert.ElementsMatch(t, []string{VersionStagePending, VersionStageCurrent}, api.stages["v2"])
+
+	// the retry clears AWSPENDING without moving AWSCURRENT again
+	assert.NoError(t, r.Handle(ctx, event))
+	assert.Equal(t, []string{VersionStageCurrent}, api.stages["v2"])
+	assert.Equal(t, []string{VersionStagePrevious}, api.stages["v1"])
+}
+
+func TestRotatorErrors(t *testing.T) {
+	api := &fakeRotationAPI{
+		values: map[string]string{"v1": "pw"},
+		stages: map[string][]string{"v1": {VersionStageCurrent}, "v2": {VersionStagePending}},
+	}
+	testErr := errors.New("login failed")
+	r := &Rotator{api: api, strategy: &recordingStrategy{testErr: testErr}}
+	ctx := goctx.Background()
+
+	assert.Error(t, r.Handle(ctx, RotationEvent{Step: RotationStepCreateSecret, SecretID: "prod/db", ClientRequestToken: "unknown"}))
+	assert.Error(t, r.Handle(ctx, RotationEvent{Step: "rollback", SecretID: "prod/db", ClientRequestToken: "v2"}))
+
+	assert.NoError(t, r.Handle(ctx, RotationEvent{Step: RotationStepCreateSecret, SecretID: "prod/db", ClientRequestToken: "v2"}))
+	assert.Equal(t, testErr, r.Handle(ctx, RotationEvent{Step: RotationStepTestSecret, SecretID: "prod/db", ClientRequestToken: "v2"}))
+}
@@ -4,10 +4,16 @@ import (
 	"encoding/json"
 	"sync"
 
+	goctx "context"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	configv2 "github.com/aws/aws-sdk-go-v2/config"
+	credentialsv2 "github.com/aws/aws-sdk-go-v2/credentials"
+	secretsmanagerv2 "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 )
 
 // GetSecretResponse response for get secret
@@ -64,6 +70,26 @@ func (s *Service) client() *secretsmanager.SecretsManager {
 	return instance
 }
 
+var oncev2 sync.Once
+var instancev2 *secretsmanagerv2.Client
+
+// clientv2 initializes AWS SDK v2 Secrets Manager client, needed for BatchGetSecretValue
+func (s *Service) clientv2() *secretsmanagerv2.Client {
+	oncev2.Do(func() {
+		cfg, err := configv2.LoadDefaultConfig(goctx.TODO(),
+			configv2.WithRegion(s.GetRegion()),
+			configv2.WithCredentialsProvider(credentialsv2.NewStaticCredentialsProvider(s.accessKey, s.accessSecret, "")),
+		)
+		if err != nil {
+			panic("failed to load AWS configuration: " + err.Error())
+		}
+
+		instancev2 = secretsmanagerv2.NewFromConfig(cfg)
+	})
+
+	return instancev2
+}
+
 // GetSecretValue get secret value by secret id, secret id can be either ARN or secret name
 func (s *Service) GetSecretValue(secretID string) (resp *GetSecretResponse) {
 	resp = new(GetSecretResponse)
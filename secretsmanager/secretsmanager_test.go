@@ -14,3 +14,14 @@ func TestGetSecretValue(t *testing.T) {
 	resp := svc.GetSecretValue("woodstock-api-local")
 	assert.NoError(t, resp.Error)
 }
+
+// TestDecodeSecretValue test decode secret value into a struct
+func TestDecodeSecretValue(t *testing.T) {
+	svc := NewService(os.Getenv("WS_SECRETS_MANAGER_AWS_ACCESS_KEY_ID"), os.Getenv("WS_SECRETS_MANAGER_AWS_SECRET_ACCESS_KEY"))
+	svc.SetRegion("ap-northeast-1")
+
+	var secret map[string]interface{}
+	resp := svc.DecodeSecretValue(&GetSecretValueOptions{SecretID: "woodstock-api-local", VersionStage: VersionStageCurrent}, &secret)
+	assert.NoError(t, resp.Error)
+	assert.Contains(t, resp.VersionStages, VersionStageCurrent)
+}
@@ -0,0 +1,146 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+const (
+	// VersionStageCurrent staging label of the current secret version
+	VersionStageCurrent = "AWSCURRENT"
+	// VersionStagePrevious staging label of the version before the current one
+	VersionStagePrevious = "AWSPREVIOUS"
+	// VersionStagePending staging label of a version being rotated in
+	VersionStagePending = "AWSPENDING"
+)
+
+// GetSecretValueOptions get secret value options, VersionID takes precedence over VersionStage,
+// AWSCURRENT is used when both are empty
+type GetSecretValueOptions struct {
+	// SecretID either ARN or secret name
+	SecretID     string
+	VersionID    string
+	VersionStage string
+	Timeout      time.Duration
+}
+
+// SecretVersion version the secret value was read from
+type SecretVersion struct {
+	ARN           string
+	Name          string
+	VersionID     string
+	VersionStages []string
+	CreatedDate   time.Time
+}
+
+// GetSecretStringResponse get raw secret string response
+type GetSecretStringResponse struct {
+	SecretVersion
+	Value string
+	Error error
+}
+
+// GetSecretBinaryResponse get raw secret bytes response
+type GetSecretBinaryResponse struct {
+	SecretVersion
+	Value []byte
+	Error error
+}
+
+// DecodeSecretValueResponse decode secret value response
+type DecodeSecretValueResponse struct {
+	SecretVersion
+	Error error
+}
+
+// GetSecretString gets the plaintext secret string, binary secrets are returned as is
+func (s *Service) GetSecretString(opts *GetSecretValueOptions) (resp *GetSecretStringResponse) {
+	resp = new(GetSecretStringResponse)
+
+	output, err := s.getSecretValue(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SecretVersion = toSecretVersion(output)
+	resp.Value = string(secretBytes(output))
+	return
+}
+
+// GetSecretBinary gets the secret bytes, SecretBinary when set, otherwise the bytes of SecretString
+func (s *Service) GetSecretBinary(opts *GetSecretValueOptions) (resp *GetSecretBinaryResponse) {
+	resp = new(GetSecretBinaryResponse)
+
+	output, err := s.getSecretValue(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SecretVersion = toSecretVersion(output)
+	resp.Value = secretBytes(output)
+	return
+}
+
+// DecodeSecretValue decodes a JSON secret into v, which can be any type json.Unmarshal accepts
+func (s *Service) DecodeSecretValue(opts *GetSecretValueOptions, v interface{}) (resp *DecodeSecretValueResponse) {
+	resp = new(DecodeSecretValueResponse)
+
+	output, err := s.getSecretValue(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SecretVersion = toSecretVersion(output)
+	resp.Error = json.Unmarshal(secretBytes(output), v)
+	return
+}
+
+// getSecretValue gets the secret version selected by opts
+func (s *Service) getSecretValue(opts *GetSecretValueOptions) (*secretsmanager.GetSecretValueOutput, error) {
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	input := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(opts.SecretID),
+	}
+
+	if opts.VersionID != "" {
+		input.VersionId = aws.String(opts.VersionID)
+	} else if opts.VersionStage != "" {
+		input.VersionStage = aws.String(opts.VersionStage)
+	}
+
+	return s.client().GetSecretValueWithContext(ctx, input)
+}
+
+// secretBytes raw secret payload, the SDK already base64 decodes SecretBinary
+func secretBytes(output *secretsmanager.GetSecretValueOutput) []byte {
+	if output.SecretString != nil {
+		return []byte(aws.StringValue(output.SecretString))
+	}
+
+	return output.SecretBinary
+}
+
+// toSecretVersion version metadata of a secret value
+func toSecretVersion(output *secretsmanager.GetSecretValueOutput) SecretVersion {
+	return SecretVersion{
+		ARN:           aws.StringValue(output.ARN),
+		Name:          aws.StringValue(output.Name),
+		VersionID:     aws.StringValue(output.VersionId),
+		VersionStages: aws.StringValueSlice(output.VersionStages),
+		CreatedDate:   aws.TimeValue(output.CreatedDate),
+	}
+}
@@ -0,0 +1,15 @@
+package secretsmanager
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretBytes(t *testing.T) {
+	assert.Equal(t, []byte(`{"port":5432}`), secretBytes(&secretsmanager.GetSecretValueOutput{SecretString: aws.String(`{"port":5432}`)}))
+	assert.Equal(t, []byte{0x00, 0xff}, secretBytes(&secretsmanager.GetSecretValueOutput{SecretBinary: []byte{0x00, 0xff}}))
+	assert.Equal(t, []byte(""), secretBytes(&secretsmanager.GetSecretValueOutput{SecretString: aws.String("")}))
+}
@@ -0,0 +1,340 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// ErrSecretPayload more than one of Value, SecretString and SecretBinary was given
+var ErrSecretPayload = errors.New("secretsmanager: set only one of Value, SecretString and SecretBinary")
+
+// SecretPayload secret contents, Value is serialized to JSON, set at most one field
+type SecretPayload struct {
+	Value        interface{}
+	SecretString string
+	SecretBinary []byte
+}
+
+// CreateSecretOptions create secret options
+type CreateSecretOptions struct {
+	SecretPayload
+	Name        string
+	Description string
+	// KmsKeyID defaults to the aws/secretsmanager managed key
+	KmsKeyID string
+	Tag      map[string]string
+	// ClientRequestToken optional idempotency token, becomes the version id
+	ClientRequestToken string
+	Timeout            time.Duration
+}
+
+// PutSecretValueOptions put secret version options
+type PutSecretValueOptions struct {
+	SecretPayload
+	SecretID string
+	// VersionStages labels of the new version, defaults to AWSCURRENT
+	VersionStages      []string
+	ClientRequestToken string
+	Timeout            time.Duration
+}
+
+// UpdateSecretOptions update secret metadata options, nil fields are left unchanged
+type UpdateSecretOptions struct {
+	SecretID    string
+	Description *string
+	KmsKeyID    *string
+	Timeout     time.Duration
+}
+
+// DeleteSecretOptions delete secret options
+type DeleteSecretOptions struct {
+	SecretID string
+	// RecoveryWindowInDays 7 to 30 days, defaults to 30
+	RecoveryWindowInDays int64
+	// ForceDelete deletes immediately without a recovery window
+	ForceDelete bool
+	Timeout     time.Duration
+}
+
+// RestoreSecretOptions restore secret options
+type RestoreSecretOptions struct {
+	SecretID string
+	Timeout  time.Duration
+}
+
+// TagSecretOptions tag secret options
+type TagSecretOptions struct {
+	SecretID string
+	Tag      map[string]string
+	Timeout  time.Duration
+}
+
+// UntagSecretOptions untag secret options
+type UntagSecretOptions struct {
+	SecretID string
+	TagKeys  []string
+	Timeout  time.Duration
+}
+
+// WriteSecretResponse create secret and put secret value response
+type WriteSecretResponse struct {
+	ARN       string
+	Name      string
+	VersionID string
+	Error     error
+}
+
+// DeleteSecretResponse delete secret response
+type DeleteSecretResponse struct {
+	ARN          string
+	Name         string
+	DeletionDate time.Time
+	Error        error
+}
+
+// SecretResponse response of secret operations returning nothing but an error
+type SecretResponse struct {
+	Error error
+}
+
+// CreateSecret creates a secret with its first version
+func (s *Service) CreateSecret(opts *CreateSecretOptions) (resp *WriteSecretResponse) {
+	resp = new(WriteSecretResponse)
+
+	input := &secretsmanager.CreateSecretInput{
+		Name: aws.String(opts.Name),
+		Tags: toTags(opts.Tag),
+	}
+
+	if err := opts.SecretPayload.apply(&input.SecretString, &input.SecretBinary); err != nil {
+		resp.Error = err
+		return
+	}
+
+	if opts.Description != "" {
+		input.Description = aws.String(opts.Description)
+	}
+
+	if opts.KmsKeyID != "" {
+		input.KmsKeyId = aws.String(opts.KmsKeyID)
+	}
+
+	if opts.ClientRequestToken != "" {
+		input.ClientRequestToken = aws.String(opts.ClientRequestToken)
+	}
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := s.client().CreateSecretWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.ARN = aws.StringValue(output.ARN)
+	resp.Name = aws.StringValue(output.Name)
+	resp.VersionID = aws.StringValue(output.VersionId)
+	return
+}
+
+// PutSecretValue stores a new secret version with the given staging labels
+func (s *Service) PutSecretValue(opts *PutSecretValueOptions) (resp *WriteSecretResponse) {
+	resp = new(WriteSecretResponse)
+
+	input := &secretsmanager.PutSecretValueInput{
+		SecretId:      aws.String(opts.SecretID),
+		VersionStages: aws.StringSlice(opts.VersionStages),
+	}
+
+	if err := opts.SecretPayload.apply(&input.SecretString, &input.SecretBinary); err != nil {
+		resp.Error = err
+		return
+	}
+
+	if opts.ClientRequestToken != "" {
+		input.ClientRequestToken = aws.String(opts.ClientRequestToken)
+	}
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := s.client().PutSecretValueWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.ARN = aws.StringValue(output.ARN)
+	resp.Name = aws.StringValue(output.Name)
+	resp.VersionID = aws.StringValue(output.VersionId)
+	return
+}
+
+// UpdateSecret updates the description and KMS key of a secret
+func (s *Service) UpdateSecret(opts *UpdateSecretOptions) (resp *SecretResponse) {
+	resp = new(SecretResponse)
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, resp.Error = s.client().UpdateSecretWithContext(ctx, &secretsmanager.UpdateSecretInput{
+		SecretId:    aws.String(opts.SecretID),
+		Description: opts.Description,
+		KmsKeyId:    opts.KmsKeyID,
+	})
+
+	return
+}
+
+// DeleteSecret schedules a secret for deletion after the recovery window, or deletes it immediately with ForceDelete
+func (s *Service) DeleteSecret(opts *DeleteSecretOptions) (resp *DeleteSecretResponse) {
+	resp = new(DeleteSecretResponse)
+
+	input := &secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(opts.SecretID),
+	}
+
+	if opts.ForceDelete {
+		input.ForceDeleteWithoutRecovery = aws.Bool(true)
+	} else if opts.RecoveryWindowInDays > 0 {
+		input.RecoveryWindowInDays = aws.Int64(opts.RecoveryWindowInDays)
+	}
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := s.client().DeleteSecretWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.ARN = aws.StringValue(output.ARN)
+	resp.Name = aws.StringValue(output.Name)
+	resp.DeletionDate = aws.TimeValue(output.DeletionDate)
+	return
+}
+
+// RestoreSecret cancels the scheduled deletion of a secret
+func (s *Service) RestoreSecret(opts *RestoreSecretOptions) (resp *SecretResponse) {
+	resp = new(SecretResponse)
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, resp.Error = s.client().RestoreSecretWithContext(ctx, &secretsmanager.RestoreSecretInput{
+		SecretId: aws.String(opts.SecretID),
+	})
+
+	return
+}
+
+// TagSecret adds or overwrites tags of a secret
+func (s *Service) TagSecret(opts *TagSecretOptions) (resp *SecretResponse) {
+	resp = new(SecretResponse)
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, resp.Error = s.client().TagResourceWithContext(ctx, &secretsmanager.TagResourceInput{
+		SecretId: aws.String(opts.SecretID),
+		Tags:     toTags(opts.Tag),
+	})
+
+	return
+}
+
+// UntagSecret removes tags of a secret
+func (s *Service) UntagSecret(opts *UntagSecretOptions) (resp *SecretResponse) {
+	resp = new(SecretResponse)
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, resp.Error = s.client().UntagResourceWithContext(ctx, &secretsmanager.UntagResourceInput{
+		SecretId: aws.String(opts.SecretID),
+		TagKeys:  aws.StringSlice(opts.TagKeys),
+	})
+
+	return
+}
+
+// apply sets the secret string or binary of an input, empty payloads leave both unset
+func (p *SecretPayload) apply(secretString **string, secretBinary *[]byte) error {
+	set := 0
+	if p.Value != nil {
+		set++
+	}
+
+	if p.SecretString != "" {
+		set++
+	}
+
+	if p.SecretBinary != nil {
+		set++
+	}
+
+	if set > 1 {
+		return ErrSecretPayload
+	}
+
+	switch {
+	case p.Value != nil:
+		b, err := json.Marshal(p.Value)
+		if err != nil {
+			return err
+		}
+		*secretString = aws.String(string(b))
+	case p.SecretString != "":
+		*secretString = aws.String(p.SecretString)
+	case p.SecretBinary != nil:
+		*secretBinary = p.SecretBinary
+	}
+
+	return nil
+}
+
+// toTags secrets manager tags from a map
+func toTags(tags map[string]string) []*secretsmanager.Tag {
+	var result []*secretsmanager.Tag
+	for k, v := range tags {
+		result = append(result, &secretsmanager.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	return result
+}
@@ -0,0 +1,27 @@
+package secretsmanager
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretPayload(t *testing.T) {
+	var secretString *string
+	var secretBinary []byte
+
+	payload := &SecretPayload{Value: map[string]interface{}{"host": "db.local", "port": 5432}}
+	assert.NoError(t, payload.apply(&secretString, &secretBinary))
+	assert.JSONEq(t, `{"host":"db.local","port":5432}`, aws.StringValue(secretString))
+	assert.Nil(t, secretBinary)
+
+	secretString = nil
+	payload = &SecretPayload{SecretBinary: []byte{0x01}}
+	assert.NoError(t, payload.apply(&secretString, &secretBinary))
+	assert.Nil(t, secretString)
+	assert.Equal(t, []byte{0x01}, secretBinary)
+
+	payload = &SecretPayload{SecretString: "plain", SecretBinary: []byte{0x01}}
+	assert.Equal(t, ErrSecretPayload, payload.apply(&secretString, &secretBinary))
+}
@@ -0,0 +1,309 @@
+package ses
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	sesv2 "github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// BulkEmailChunkSize SES accepts at most 50 destinations per SendBulkEmail call
+const BulkEmailChunkSize = 50
+
+// ErrSendQuotaExceeded the destination was not sent as the 24 hour send quota is used up
+var ErrSendQuotaExceeded = errors.New("ses: 24 hour send quota exceeded")
+
+// BulkEmailDestination destination with its own template data and tags
+type BulkEmailDestination struct {
+	Recipients   []string
+	CCs          []string
+	BCCs         []string
+	TemplateData map[string]string
+	Tag          map[string]string
+}
+
+// SendBulkEmailOptions send bulk templated email options
+type SendBulkEmailOptions struct {
+	Sender   string
+	ReplyTo  []string
+	Template string
+	// DefaultTemplateData used for destinations without TemplateData
+	DefaultTemplateData map[string]string
+	Destinations        []*BulkEmailDestination
+	// Tag default tags, overridden per destination
+	Tag              map[string]string
+	ConfigurationSet *string
+	// MaxSendRate recipients per second, defaults to the account send quota
+	MaxSendRate float64
+	// Timeout per SendBulkEmail call
+	Timeout time.Duration
+}
+
+// BulkEmailResult send status of a single destination
+type BulkEmailResult struct {
+	Destination *BulkEmailDestination
+	MessageID   string
+	Status      string
+	Error       error
+}
+
+// SendBulkEmailResponse send bulk templated email response, one result per destination in order
+type SendBulkEmailResponse struct {
+	Results []*BulkEmailResult
+	Error   error
+}
+
+// GetSendQuotaOptions account send quota options
+type GetSendQuotaOptions struct {
+	Timeout time.Duration
+}
+
+// GetSendQuotaResponse account send quota response
+type GetSendQuotaResponse struct {
+	Max24HourSend   float64
+	MaxSendRate     float64
+	SentLast24Hours float64
+	Error           error
+}
+
+// rateLimiter paces sends to a number of recipients per second
+type rateLimiter struct {
+	rate  float64
+	next  time.Time
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// GetSendQuota gets the account send quota
+func (s *Service) GetSendQuota(opts *GetSendQuotaOptions) (resp *GetSendQuotaResponse) {
+	resp = new(GetSendQuotaResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := s.clientv2().GetAccount(ctx, new(sesv2.GetAccountInput))
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	if output.SendQuota != nil {
+		resp.Max24HourSend = output.SendQuota.Max24HourSend
+		resp.MaxSendRate = output.SendQuota.MaxSendRate
+		resp.SentLast24Hours = output.SendQuota.SentLast24Hours
+	}
+
+	return
+}
+
+// SendBulkEmail sends a template to many destinations, each with its own data and tags,
+// in chunks of BulkEmailChunkSize paced to the account send rate
+func (s *Service) SendBulkEmail(opts *SendBulkEmailOptions) (resp *SendBulkEmailResponse) {
+	resp = new(SendBulkEmailResponse)
+	if s.local != nil {
+		for _, dest := range opts.Destinations {
+			data := dest.TemplateData
+			if len(data) == 0 {
+				data = opts.DefaultTemplateData
+			}
+
+			result := &BulkEmailResult{Destination: dest, Status: string(sesv2types.BulkEmailStatusSuccess)}
+			result.MessageID, result.Error = s.local.sendTemplated(opts.Sender, dest.Recipients, dest.CCs, dest.BCCs, opts.Template, data, opts.ReplyTo)
+			if result.Error != nil {
+				result.Status = string(sesv2types.BulkEmailStatusFailed)
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		return
+	}
+
+	quota := s.GetSendQuota(&GetSendQuotaOptions{Timeout: opts.Timeout})
+	if quota.Error != nil {
+		resp.Error = quota.Error
+		return
+	}
+
+	rate := opts.MaxSendRate
+	if rate <= 0 {
+		rate = quota.MaxSendRate
+	}
+	limiter := newRateLimiter(rate)
+	remaining := quota.Max24HourSend - quota.SentLast24Hours
+
+	defaultData, err := marshalTemplateData(opts.DefaultTemplateData)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	for _, chunk := range chunkBulkDestinations(opts.Destinations, BulkEmailChunkSize) {
+		recipients := countBulkRecipients(chunk)
+		if quota.Max24HourSend > 0 && float64(recipients) > remaining {
+			resp.Results = append(resp.Results, failedBulkResults(chunk, ErrSendQuotaExceeded)...)
+			continue
+		}
+
+		input := &sesv2.SendBulkEmailInput{
+			FromEmailAddress: aws.String(opts.Sender),
+			ReplyToAddresses: opts.ReplyTo,
+			DefaultContent: &sesv2types.BulkEmailContent{
+				Template: &sesv2types.Template{
+					TemplateName: aws.String(opts.Template),
+					TemplateData: aws.String(defaultData),
+				},
+			},
+			DefaultEmailTags:     toMessageTagsV2(opts.Tag),
+			ConfigurationSetName: opts.ConfigurationSet,
+		}
+
+		for _, dest := range chunk {
+			entry := sesv2types.BulkEmailEntry{
+				Destination:     toDestinationV2(dest.Recipients, dest.CCs, dest.BCCs),
+				ReplacementTags: toMessageTagsV2(dest.Tag),
+			}
+
+			if len(dest.TemplateData) > 0 {
+				data, err := marshalTemplateData(dest.TemplateData)
+				if err != nil {
+					resp.Error = err
+					return
+				}
+
+				entry.ReplacementEmailContent = &sesv2types.ReplacementEmailContent{
+					ReplacementTemplate: &sesv2types.ReplacementTemplate{ReplacementTemplateData: aws.String(data)},
+				}
+			}
+
+			input.BulkEmailEntries = append(input.BulkEmailEntries, entry)
+		}
+
+		limiter.wait(recipients)
+		output, err := s.sendBulkChunk(client, input, t)
+		if err != nil {
+			resp.Results = append(resp.Results, failedBulkResults(chunk, err)...)
+			continue
+		}
+
+		remaining -= float64(recipients)
+		for i, dest := range chunk {
+			if i >= len(output.BulkEmailEntryResults) {
+				resp.Results = append(resp.Results, failedBulkResults([]*BulkEmailDestination{dest}, errors.New("ses: no result for destination"))...)
+				continue
+			}
+
+			entry := output.BulkEmailEntryResults[i]
+			result := &BulkEmailResult{
+				Destination: dest,
+				MessageID:   aws.StringValue(entry.MessageId),
+				Status:      string(entry.Status),
+			}
+			if entry.Status != sesv2types.BulkEmailStatusSuccess {
+				result.Error = errors.New(result.Status + ": " + aws.StringValue(entry.Error))
+			}
+			resp.Results = append(resp.Results, result)
+		}
+	}
+
+	return
+}
+
+// failedBulkResults failed results of destinations which were not sent
+func failedBulkResults(destinations []*BulkEmailDestination, err error) []*BulkEmailResult {
+	results := make([]*BulkEmailResult, 0, len(destinations))
+	for _, dest := range destinations {
+		results = append(results, &BulkEmailResult{
+			Destination: dest,
+			Status:      string(sesv2types.BulkEmailStatusFailed),
+			Error:       err,
+		})
+	}
+
+	return results
+}
+
+// sendBulkChunk sends a single SendBulkEmail call
+func (s *Service) sendBulkChunk(client *sesv2.Client, input *sesv2.SendBulkEmailInput, timeout time.Duration) (*sesv2.SendBulkEmailOutput, error) {
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), timeout)
+	defer cancel()
+
+	return client.SendBulkEmail(ctx, input)
+}
+
+// marshalTemplateData encodes template data, SES requires at least "{}"
+func marshalTemplateData(data map[string]string) (string, error) {
+	if len(data) == 0 {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(data)
+	return string(b), err
+}
+
+// chunkBulkDestinations splits destinations into chunks of at most size
+func chunkBulkDestinations(dests []*BulkEmailDestination, size int) [][]*BulkEmailDestination {
+	var chunks [][]*BulkEmailDestination
+	for len(dests) > size {
+		chunks = append(chunks, dests[:size])
+		dests = dests[size:]
+	}
+
+	if len(dests) > 0 {
+		chunks = append(chunks, dests)
+	}
+
+	return chunks
+}
+
+// countBulkRecipients counts recipients, SES rates and quotas count every address
+func countBulkRecipients(dests []*BulkEmailDestination) int {
+	n := 0
+	for _, dest := range dests {
+		n += len(dest.Recipients) + len(dest.CCs) + len(dest.BCCs)
+	}
+
+	return n
+}
+
+// newRateLimiter rate limiter initializer, a non-positive rate never waits
+func newRateLimiter(rate float64) *rateLimiter {
+	return &rateLimiter{
+		rate:  rate,
+		now:   time.Now,
+		sleep: time.Sleep,
+	}
+}
+
+// wait blocks until n more recipients may be sent
+func (l *rateLimiter) wait(n int) {
+	if l.rate <= 0 {
+		return
+	}
+
+	now := l.now()
+	if l.next.After(now) {
+		l.sleep(l.next.Sub(now))
+		now = l.next
+	}
+
+	l.next = now.Add(time.Duration(float64(n) / l.rate * float64(time.Second)))
+}
@@ -0,0 +1,57 @@
+package ses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkBulkDestinations(t *testing.T) {
+	dests := make([]*BulkEmailDestination, 120)
+	for i := range dests {
+		dests[i] = &BulkEmailDestination{Recipients: []string{"min@woodstock.club"}}
+	}
+
+	chunks := chunkBulkDestinations(dests, BulkEmailChunkSize)
+	assert.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 50)
+	assert.Len(t, chunks[1], 50)
+	assert.Len(t, chunks[2], 20)
+	assert.Empty(t, chunkBulkDestinations(nil, BulkEmailChunkSize))
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+	limiter := newRateLimiter(14)
+	limiter.now = func() time.Time { return clock }
+	limiter.sleep = func(d time.Duration) {
+		slept = append(slept, d)
+		clock = clock.Add(d)
+	}
+
+	limiter.wait(7)
+	limiter.wait(14)
+	limiter.wait(1)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, slept)
+
+	unlimited := newRateLimiter(0)
+	unlimited.sleep = func(d time.Duration) { t.Fatal("unexpected sleep") }
+	unlimited.wait(100)
+}
+
+func TestFailedBulkResults(t *testing.T) {
+	dests := []*BulkEmailDestination{
+		{Recipients: []string{"min@woodstock.club"}},
+		{Recipients: []string{"taro@woodstock.club"}},
+	}
+
+	results := failedBulkResults(dests, ErrSendQuotaExceeded)
+	assert.Len(t, results, 2)
+	for i, result := range results {
+		assert.Equal(t, dests[i], result.Destination)
+		assert.Equal(t, "FAILED", result.Status)
+		assert.ErrorIs(t, result.Error, ErrSendQuotaExceeded)
+	}
+}
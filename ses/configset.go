@@ -0,0 +1,318 @@
+package ses
+
+import (
+	"fmt"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	sesv2 "github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// destinationEventTypes event destination names of event types
+var destinationEventTypes = map[EventType]sesv2types.EventType{
+	EventTypeSend:             sesv2types.EventTypeSend,
+	EventTypeReject:           sesv2types.EventTypeReject,
+	EventTypeBounce:           sesv2types.EventTypeBounce,
+	EventTypeComplaint:        sesv2types.EventTypeComplaint,
+	EventTypeDelivery:         sesv2types.EventTypeDelivery,
+	EventTypeOpen:             sesv2types.EventTypeOpen,
+	EventTypeClick:            sesv2types.EventTypeClick,
+	EventTypeRenderingFailure: sesv2types.EventTypeRenderingFailure,
+	EventTypeDeliveryDelay:    sesv2types.EventTypeDeliveryDelay,
+	EventTypeSubscription:     sesv2types.EventTypeSubscription,
+}
+
+// CreateConfigurationSetOptions create configuration set options
+type CreateConfigurationSetOptions struct {
+	Name string
+	// SendingPoolName dedicated IP pool, empty uses the shared pool
+	SendingPoolName string
+	// TLSRequired only deliver over TLS
+	TLSRequired bool
+	// CustomRedirectDomain domain used for open and click tracking links
+	CustomRedirectDomain string
+	// SuppressedReasons overrides the account level suppression list reasons
+	SuppressedReasons []SuppressionReason
+	Tags              map[string]string
+	Timeout           time.Duration
+}
+
+// CreateConfigurationSetResponse create configuration set response
+type CreateConfigurationSetResponse struct {
+	Error error
+}
+
+// DeleteConfigurationSetOptions delete configuration set options
+type DeleteConfigurationSetOptions struct {
+	Name    string
+	Timeout time.Duration
+}
+
+// DeleteConfigurationSetResponse delete configuration set response
+type DeleteConfigurationSetResponse struct {
+	Error error
+}
+
+// ListConfigurationSetsOptions list configuration sets options
+type ListConfigurationSetsOptions struct {
+	// Timeout per page
+	Timeout time.Duration
+}
+
+// ListConfigurationSetsResponse list configuration sets response
+type ListConfigurationSetsResponse struct {
+	ConfigurationSets []string
+	Error             error
+}
+
+// SNSEventDestination publishes events to an SNS topic
+type SNSEventDestination struct {
+	TopicArn string
+}
+
+// CloudWatchEventDestination publishes events as CloudWatch metrics
+type CloudWatchEventDestination struct {
+	Dimensions []CloudWatchDimension
+}
+
+// CloudWatchDimension CloudWatch metric dimension
+type CloudWatchDimension struct {
+	Name string
+	// Source one of MESSAGE_TAG, EMAIL_HEADER or LINK_TAG
+	Source       string
+	DefaultValue string
+}
+
+// FirehoseEventDestination publishes events to a Kinesis Data Firehose stream
+type FirehoseEventDestination struct {
+	DeliveryStreamArn string
+	IAMRoleArn        string
+}
+
+// CreateEventDestinationOptions create configuration set event destination options, set exactly one destination
+type CreateEventDestinationOptions struct {
+	ConfigurationSet string
+	Name             string
+	EventTypes       []EventType
+	// Disabled creates the destination without publishing to it
+	Disabled   bool
+	SNS        *SNSEventDestination
+	CloudWatch *CloudWatchEventDestination
+	Firehose   *FirehoseEventDestination
+	Timeout    time.Duration
+}
+
+// CreateEventDestinationResponse create configuration set event destination response
+type CreateEventDestinationResponse struct {
+	Error error
+}
+
+// CreateConfigurationSet creates a configuration set
+func (s *Service) CreateConfigurationSet(opts *CreateConfigurationSetOptions) (resp *CreateConfigurationSetResponse) {
+	resp = new(CreateConfigurationSetResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	input := &sesv2.CreateConfigurationSetInput{
+		ConfigurationSetName: aws.String(opts.Name),
+		Tags:                 toTagsV2(opts.Tags),
+	}
+
+	if opts.SendingPoolName != "" || opts.TLSRequired {
+		input.DeliveryOptions = new(sesv2types.DeliveryOptions)
+		if opts.SendingPoolName != "" {
+			input.DeliveryOptions.SendingPoolName = aws.String(opts.SendingPoolName)
+		}
+		if opts.TLSRequired {
+			input.DeliveryOptions.TlsPolicy = sesv2types.TlsPolicyRequire
+		}
+	}
+
+	if opts.CustomRedirectDomain != "" {
+		input.TrackingOptions = &sesv2types.TrackingOptions{CustomRedirectDomain: aws.String(opts.CustomRedirectDomain)}
+	}
+
+	if opts.SuppressedReasons != nil {
+		input.SuppressionOptions = new(sesv2types.SuppressionOptions)
+		for _, reason := range opts.SuppressedReasons {
+			input.SuppressionOptions.SuppressedReasons = append(input.SuppressionOptions.SuppressedReasons, sesv2types.SuppressionListReason(reason))
+		}
+	}
+
+	_, err := client.CreateConfigurationSet(ctx, input)
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// DeleteConfigurationSet deletes a configuration set and its event destinations
+func (s *Service) DeleteConfigurationSet(opts *DeleteConfigurationSetOptions) (resp *DeleteConfigurationSetResponse) {
+	resp = new(DeleteConfigurationSetResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.DeleteConfigurationSet(ctx, &sesv2.DeleteConfigurationSetInput{
+		ConfigurationSetName: aws.String(opts.Name),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// ListConfigurationSets lists every configuration set in the region
+func (s *Service) ListConfigurationSets(opts *ListConfigurationSetsOptions) (resp *ListConfigurationSetsResponse) {
+	resp = new(ListConfigurationSetsResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	paginator := sesv2.NewListConfigurationSetsPaginator(s.clientv2(), new(sesv2.ListConfigurationSetsInput))
+	for paginator.HasMorePages() {
+		ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+		page, err := paginator.NextPage(ctx)
+		cancel()
+
+		if err != nil {
+			resp.Error = err
+			return
+		}
+
+		resp.ConfigurationSets = append(resp.ConfigurationSets, page.ConfigurationSets...)
+	}
+
+	return
+}
+
+// CreateEventDestination attaches an SNS, CloudWatch or Firehose event destination to a configuration set
+func (s *Service) CreateEventDestination(opts *CreateEventDestinationOptions) (resp *CreateEventDestinationResponse) {
+	resp = new(CreateEventDestinationResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	definition, err := eventDestinationDefinition(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err = client.CreateConfigurationSetEventDestination(ctx, &sesv2.CreateConfigurationSetEventDestinationInput{
+		ConfigurationSetName: aws.String(opts.ConfigurationSet),
+		EventDestinationName: aws.String(opts.Name),
+		EventDestination:     definition,
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// eventDestinationDefinition builds the sesv2 event destination from opts
+func eventDestinationDefinition(opts *CreateEventDestinationOptions) (*sesv2types.EventDestinationDefinition, error) {
+	definition := &sesv2types.EventDestinationDefinition{
+		Enabled: !opts.Disabled,
+	}
+
+	if len(opts.EventTypes) == 0 {
+		return nil, fmt.Errorf("ses: event destination %s has no event types", opts.Name)
+	}
+
+	for _, eventType := range opts.EventTypes {
+		matching, ok := destinationEventTypes[eventType]
+		if !ok {
+			return nil, fmt.Errorf("ses: unknown event type %q", eventType)
+		}
+		definition.MatchingEventTypes = append(definition.MatchingEventTypes, matching)
+	}
+
+	destinations := 0
+	if opts.SNS != nil {
+		destinations++
+		definition.SnsDestination = &sesv2types.SnsDestination{TopicArn: aws.String(opts.SNS.TopicArn)}
+	}
+
+	if opts.CloudWatch != nil {
+		destinations++
+		definition.CloudWatchDestination = new(sesv2types.CloudWatchDestination)
+		for _, d := range opts.CloudWatch.Dimensions {
+			definition.CloudWatchDestination.DimensionConfigurations = append(definition.CloudWatchDestination.DimensionConfigurations, sesv2types.CloudWatchDimensionConfiguration{
+				DimensionName:         aws.String(d.Name),
+				DimensionValueSource:  sesv2types.DimensionValueSource(d.Source),
+				DefaultDimensionValue: aws.String(d.DefaultValue),
+			})
+		}
+	}
+
+	if opts.Firehose != nil {
+		destinations++
+		definition.KinesisFirehoseDestination = &sesv2types.KinesisFirehoseDestination{
+			DeliveryStreamArn: aws.String(opts.Firehose.DeliveryStreamArn),
+			IamRoleArn:        aws.String(opts.Firehose.IAMRoleArn),
+		}
+	}
+
+	if destinations != 1 {
+		return nil, fmt.Errorf("ses: event destination %s must have exactly one of SNS, CloudWatch or Firehose", opts.Name)
+	}
+
+	return definition, nil
+}
+
+// toTagsV2 converts a tag map to sesv2 resource tags
+func toTagsV2(tags map[string]string) []sesv2types.Tag {
+	var result []sesv2types.Tag
+	for k, v := range tags {
+		result = append(result, sesv2types.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,33 @@
+package ses
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventDestinationDefinition(t *testing.T) {
+	definition, err := eventDestinationDefinition(&CreateEventDestinationOptions{
+		ConfigurationSet: "managed-dedicated-ip",
+		Name:             "bounces",
+		EventTypes:       []EventType{EventTypeBounce, EventTypeRenderingFailure},
+		SNS:              &SNSEventDestination{TopicArn: "arn:aws:sns:ap-northeast-1:123456789012:ses-events"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, definition.Enabled)
+	assert.Len(t, definition.MatchingEventTypes, 2)
+	assert.Equal(t, "RENDERING_FAILURE", string(definition.MatchingEventTypes[1]))
+
+	_, err = eventDestinationDefinition(&CreateEventDestinationOptions{
+		Name:       "none",
+		EventTypes: []EventType{EventTypeBounce},
+	})
+	assert.Error(t, err)
+
+	_, err = eventDestinationDefinition(&CreateEventDestinationOptions{
+		Name:       "unknown",
+		EventTypes: []EventType{"Bounced"},
+		SNS:        &SNSEventDestination{TopicArn: "arn"},
+	})
+	assert.Error(t, err)
+}
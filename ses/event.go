@@ -0,0 +1,250 @@
+package ses
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType SES event or notification type
+type EventType string
+
+const (
+	EventTypeBounce           EventType = "Bounce"
+	EventTypeComplaint        EventType = "Complaint"
+	EventTypeDelivery         EventType = "Delivery"
+	EventTypeSend             EventType = "Send"
+	EventTypeReject           EventType = "Reject"
+	EventTypeOpen             EventType = "Open"
+	EventTypeClick            EventType = "Click"
+	EventTypeDeliveryDelay    EventType = "DeliveryDelay"
+	EventTypeRenderingFailure EventType = "Rendering Failure"
+	EventTypeSubscription     EventType = "Subscription"
+)
+
+// BounceType bounce type
+type BounceType string
+
+const (
+	BounceTypeUndetermined BounceType = "Undetermined"
+	BounceTypePermanent    BounceType = "Permanent"
+	BounceTypeTransient    BounceType = "Transient"
+)
+
+// Event SES event publishing record or feedback notification
+//
+// Event publishing (configuration sets) sets EventType, feedback notifications
+// (identities) set NotificationType, use Type to read either
+type Event struct {
+	EventType        EventType              `json:"eventType,omitempty"`
+	NotificationType EventType              `json:"notificationType,omitempty"`
+	Mail             *EventMail             `json:"mail"`
+	Bounce           *EventBounce           `json:"bounce,omitempty"`
+	Complaint        *EventComplaint        `json:"complaint,omitempty"`
+	Delivery         *EventDelivery         `json:"delivery,omitempty"`
+	Send             *EventSend             `json:"send,omitempty"`
+	Reject           *EventReject           `json:"reject,omitempty"`
+	Open             *EventOpen             `json:"open,omitempty"`
+	Click            *EventClick            `json:"click,omitempty"`
+	DeliveryDelay    *EventDeliveryDelay    `json:"deliveryDelay,omitempty"`
+	RenderingFailure *EventRenderingFailure `json:"failure,omitempty"`
+}
+
+// EventMail original message an event refers to
+type EventMail struct {
+	Timestamp        time.Time           `json:"timestamp"`
+	MessageID        string              `json:"messageId"`
+	Source           string              `json:"source"`
+	SourceArn        string              `json:"sourceArn"`
+	SourceIP         string              `json:"sourceIp"`
+	SendingAccountID string              `json:"sendingAccountId"`
+	CallerIdentity   string              `json:"callerIdentity"`
+	Destination      []string            `json:"destination"`
+	HeadersTruncated bool                `json:"headersTruncated"`
+	Headers          []EventMailHeader   `json:"headers"`
+	CommonHeaders    *EventCommonHeaders `json:"commonHeaders"`
+	Tags             map[string][]string `json:"tags"`
+}
+
+// EventMailHeader original message header
+type EventMailHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// EventCommonHeaders frequently used headers of the original message
+type EventCommonHeaders struct {
+	From      []string `json:"from"`
+	To        []string `json:"to"`
+	Cc        []string `json:"cc"`
+	ReplyTo   []string `json:"replyTo"`
+	MessageID string   `json:"messageId"`
+	Subject   string   `json:"subject"`
+	Date      string   `json:"date"`
+}
+
+// EventBounce bounce details
+type EventBounce struct {
+	BounceType        BounceType              `json:"bounceType"`
+	BounceSubType     string                  `json:"bounceSubType"`
+	BouncedRecipients []EventBouncedRecipient `json:"bouncedRecipients"`
+	Timestamp         time.Time               `json:"timestamp"`
+	FeedbackID        string                  `json:"feedbackId"`
+	ReportingMTA      string                  `json:"reportingMTA"`
+	RemoteMtaIP       string                  `json:"remoteMtaIp"`
+}
+
+// EventBouncedRecipient bounced recipient
+type EventBouncedRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	Action         string `json:"action"`
+	Status         string `json:"status"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+// EventComplaint complaint details
+type EventComplaint struct {
+	ComplainedRecipients  []EventRecipient `json:"complainedRecipients"`
+	Timestamp             time.Time        `json:"timestamp"`
+	FeedbackID            string           `json:"feedbackId"`
+	ComplaintSubType      string           `json:"complaintSubType"`
+	UserAgent             string           `json:"userAgent"`
+	ComplaintFeedbackType string           `json:"complaintFeedbackType"`
+	ArrivalDate           time.Time        `json:"arrivalDate"`
+}
+
+// EventRecipient recipient address
+type EventRecipient struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+// EventDelivery delivery details
+type EventDelivery struct {
+	Timestamp            time.Time `json:"timestamp"`
+	ProcessingTimeMillis int64     `json:"processingTimeMillis"`
+	Recipients           []string  `json:"recipients"`
+	SMTPResponse         string    `json:"smtpResponse"`
+	ReportingMTA         string    `json:"reportingMTA"`
+	RemoteMtaIP          string    `json:"remoteMtaIp"`
+}
+
+// EventSend send details, SES publishes an empty object
+type EventSend struct{}
+
+// EventReject reject details
+type EventReject struct {
+	Reason string `json:"reason"`
+}
+
+// EventOpen open details
+type EventOpen struct {
+	IPAddress string    `json:"ipAddress"`
+	Timestamp time.Time `json:"timestamp"`
+	UserAgent string    `json:"userAgent"`
+}
+
+// EventClick click details
+type EventClick struct {
+	IPAddress string              `json:"ipAddress"`
+	Link      string              `json:"link"`
+	LinkTags  map[string][]string `json:"linkTags"`
+	Timestamp time.Time           `json:"timestamp"`
+	UserAgent string              `json:"userAgent"`
+}
+
+// EventDeliveryDelay delivery delay details
+type EventDeliveryDelay struct {
+	DelayType         string                  `json:"delayType"`
+	DelayedRecipients []EventDelayedRecipient `json:"delayedRecipients"`
+	ExpirationTime    time.Time               `json:"expirationTime"`
+	ReportingMTA      string                  `json:"reportingMTA"`
+	Timestamp         time.Time               `json:"timestamp"`
+}
+
+// EventDelayedRecipient delayed recipient
+type EventDelayedRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	Status         string `json:"status"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+// EventRenderingFailure rendering failure details
+type EventRenderingFailure struct {
+	TemplateName string `json:"templateName"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// SuppressHardBouncesResponse suppress hard bounces response
+type SuppressHardBouncesResponse struct {
+	Suppressed []string
+	Error      error
+}
+
+// snsEnvelope minimal SNS envelope, verify signatures with the sns package when needed
+type snsEnvelope struct {
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+// ParseEvent parses an SES event, either raw or wrapped in an SNS notification envelope as delivered to SQS
+func ParseEvent(data []byte) (*Event, error) {
+	envelope := new(snsEnvelope)
+	if err := json.Unmarshal(data, envelope); err == nil && envelope.Type == "Notification" && envelope.Message != "" {
+		data = []byte(envelope.Message)
+	}
+
+	e := new(Event)
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+
+	if e.Type() == "" {
+		return nil, fmt.Errorf("ses: message is not an SES event")
+	}
+
+	return e, nil
+}
+
+// Type event type, from either eventType or notificationType
+func (e *Event) Type() EventType {
+	if e.EventType != "" {
+		return e.EventType
+	}
+
+	return e.NotificationType
+}
+
+// HardBouncedRecipients recipients of a permanent bounce, nil for any other event
+func (e *Event) HardBouncedRecipients() []string {
+	if e.Type() != EventTypeBounce || e.Bounce == nil || e.Bounce.BounceType != BounceTypePermanent {
+		return nil
+	}
+
+	var recipients []string
+	for _, r := range e.Bounce.BouncedRecipients {
+		recipients = append(recipients, r.EmailAddress)
+	}
+
+	return recipients
+}
+
+// SuppressHardBounces adds the recipients of a permanent bounce to the account suppression list
+func (s *Service) SuppressHardBounces(e *Event) (resp *SuppressHardBouncesResponse) {
+	resp = new(SuppressHardBouncesResponse)
+
+	for _, email := range e.HardBouncedRecipients() {
+		put := s.PutSuppressedDestination(&PutSuppressedDestinationOptions{
+			Email:  email,
+			Reason: SuppressionReasonBounce,
+		})
+
+		if put.Error != nil {
+			resp.Error = put.Error
+			return
+		}
+
+		resp.Suppressed = append(resp.Suppressed, email)
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+package ses
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testBounceNotification = `{
+	"notificationType": "Bounce",
+	"bounce": {
+		"bounceType": "Permanent",
+		"bounceSubType": "General",
+		"bouncedRecipients": [
+			{"emailAddress": "bounced@woodstock.club", "action": "failed", "status": "5.1.1", "diagnosticCode": "smtp; 550 5.1.1 user unknown"}
+		],
+		"timestamp": "2024-05-01T09:30:16.000Z",
+		"feedbackId": "0100018f-feedback",
+		"reportingMTA": "dsn; a27-42.smtp-out.ap-northeast-1.amazonses.com"
+	},
+	"mail": {
+		"timestamp": "2024-05-01T09:30:15.123Z",
+		"messageId": "0100018f-message",
+		"source": "contact@woodstock.club",
+		"destination": ["bounced@woodstock.club"],
+		"commonHeaders": {"from": ["contact@woodstock.club"], "to": ["bounced@woodstock.club"], "subject": "test"}
+	}
+}`
+
+func TestParseEventSNSEnvelope(t *testing.T) {
+	envelope, err := json.Marshal(map[string]string{
+		"Type":      "Notification",
+		"MessageId": "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		"TopicArn":  "arn:aws:sns:ap-northeast-1:123456789012:ses-events",
+		"Message":   testBounceNotification,
+	})
+	assert.NoError(t, err)
+
+	e, err := ParseEvent(envelope)
+	assert.NoError(t, err)
+	assert.Equal(t, EventTypeBounce, e.Type())
+	assert.Equal(t, "0100018f-message", e.Mail.MessageID)
+	assert.Equal(t, "test", e.Mail.CommonHeaders.Subject)
+	assert.Equal(t, BounceTypePermanent, e.Bounce.BounceType)
+	assert.Equal(t, []string{"bounced@woodstock.club"}, e.HardBouncedRecipients())
+}
+
+func TestParseEventPublishing(t *testing.T) {
+	e, err := ParseEvent([]byte(`{
+		"eventType": "Click",
+		"mail": {"messageId": "0100018f-message", "tags": {"campaign": ["spring"]}},
+		"click": {"ipAddress": "192.0.2.1", "link": "https://woodstock.club", "linkTags": {"pos": ["header"]}, "timestamp": "2024-05-01T10:00:00.000Z"}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, EventTypeClick, e.Type())
+	assert.Equal(t, "https://woodstock.club", e.Click.Link)
+	assert.Equal(t, []string{"spring"}, e.Mail.Tags["campaign"])
+	assert.Nil(t, e.HardBouncedRecipients())
+
+	e, err = ParseEvent([]byte(`{
+		"eventType": "Rendering Failure",
+		"mail": {"messageId": "0100018f-message"},
+		"failure": {"templateName": "welcome", "errorMessage": "Attribute 'name' is not present in the rendering data."}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, EventTypeRenderingFailure, e.Type())
+	assert.Equal(t, "welcome", e.RenderingFailure.TemplateName)
+
+	e, err = ParseEvent([]byte(`{
+		"eventType": "DeliveryDelay",
+		"mail": {"messageId": "0100018f-message"},
+		"deliveryDelay": {"delayType": "MailboxFull", "delayedRecipients": [{"emailAddress": "min@woodstock.club", "status": "4.2.2"}], "expirationTime": "2024-05-02T10:00:00.000Z"}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "MailboxFull", e.DeliveryDelay.DelayType)
+	assert.Equal(t, "min@woodstock.club", e.DeliveryDelay.DelayedRecipients[0].EmailAddress)
+
+	_, err = ParseEvent([]byte(`{"foo": "bar"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,253 @@
+package ses
+
+import (
+	"strings"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	sesv2 "github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// dkimRecordSuffix DKIM CNAME records point to <token> under this domain
+const dkimRecordSuffix = ".dkim.amazonses.com"
+
+// DNSRecord DNS record to publish for identity verification
+type DNSRecord struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+// EmailIdentity email address or domain identity
+type EmailIdentity struct {
+	Identity string
+	// IdentityType one of EMAIL_ADDRESS, DOMAIN or MANAGED_DOMAIN
+	IdentityType       string
+	VerifiedForSending bool
+	// VerificationStatus one of PENDING, SUCCESS, FAILED, TEMPORARY_FAILURE or NOT_STARTED
+	VerificationStatus string
+	DKIMStatus         string
+	DKIMTokens         []string
+	// DKIMRecords CNAME records to publish for domain identities
+	DKIMRecords      []*DNSRecord
+	ConfigurationSet string
+}
+
+// CreateEmailIdentityOptions create email identity options, Identity is an email address or a domain
+type CreateEmailIdentityOptions struct {
+	Identity string
+	// ConfigurationSet default configuration set of the identity
+	ConfigurationSet string
+	Tags             map[string]string
+	Timeout          time.Duration
+}
+
+// GetEmailIdentityOptions get email identity options
+type GetEmailIdentityOptions struct {
+	Identity string
+	Timeout  time.Duration
+}
+
+// EmailIdentityResponse create or get email identity response
+type EmailIdentityResponse struct {
+	EmailIdentity *EmailIdentity
+	Error         error
+}
+
+// DeleteEmailIdentityOptions delete email identity options
+type DeleteEmailIdentityOptions struct {
+	Identity string
+	Timeout  time.Duration
+}
+
+// DeleteEmailIdentityResponse delete email identity response
+type DeleteEmailIdentityResponse struct {
+	Error error
+}
+
+// ListEmailIdentitiesOptions list email identities options
+type ListEmailIdentitiesOptions struct {
+	// Timeout per page
+	Timeout time.Duration
+}
+
+// ListEmailIdentitiesResponse list email identities response
+type ListEmailIdentitiesResponse struct {
+	EmailIdentities []*EmailIdentity
+	Error           error
+}
+
+// CreateEmailIdentity creates an identity and starts its verification,
+// email addresses receive a verification mail, domains must publish the returned DKIM records
+func (s *Service) CreateEmailIdentity(opts *CreateEmailIdentityOptions) (resp *EmailIdentityResponse) {
+	resp = new(EmailIdentityResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	input := &sesv2.CreateEmailIdentityInput{
+		EmailIdentity: aws.String(opts.Identity),
+		Tags:          toTagsV2(opts.Tags),
+	}
+
+	if opts.ConfigurationSet != "" {
+		input.ConfigurationSetName = aws.String(opts.ConfigurationSet)
+	}
+
+	output, err := client.CreateEmailIdentity(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.EmailIdentity = &EmailIdentity{
+		Identity:           opts.Identity,
+		IdentityType:       string(output.IdentityType),
+		VerifiedForSending: output.VerifiedForSendingStatus,
+		ConfigurationSet:   opts.ConfigurationSet,
+	}
+	resp.EmailIdentity.setDKIM(output.DkimAttributes)
+
+	return
+}
+
+// GetEmailIdentity gets the verification and DKIM status of an identity
+func (s *Service) GetEmailIdentity(opts *GetEmailIdentityOptions) (resp *EmailIdentityResponse) {
+	resp = new(EmailIdentityResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.GetEmailIdentity(ctx, &sesv2.GetEmailIdentityInput{
+		EmailIdentity: aws.String(opts.Identity),
+	})
+
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.EmailIdentity = &EmailIdentity{
+		Identity:           opts.Identity,
+		IdentityType:       string(output.IdentityType),
+		VerifiedForSending: output.VerifiedForSendingStatus,
+		VerificationStatus: string(output.VerificationStatus),
+		ConfigurationSet:   aws.StringValue(output.ConfigurationSetName),
+	}
+	resp.EmailIdentity.setDKIM(output.DkimAttributes)
+
+	return
+}
+
+// DeleteEmailIdentity deletes an identity
+func (s *Service) DeleteEmailIdentity(opts *DeleteEmailIdentityOptions) (resp *DeleteEmailIdentityResponse) {
+	resp = new(DeleteEmailIdentityResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.DeleteEmailIdentity(ctx, &sesv2.DeleteEmailIdentityInput{
+		EmailIdentity: aws.String(opts.Identity),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// ListEmailIdentities lists every identity in the region, without DKIM details
+func (s *Service) ListEmailIdentities(opts *ListEmailIdentitiesOptions) (resp *ListEmailIdentitiesResponse) {
+	resp = new(ListEmailIdentitiesResponse)
+	if s.local != nil {
+		resp.Error = ErrLocalUnsupported
+		return
+	}
+
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	paginator := sesv2.NewListEmailIdentitiesPaginator(s.clientv2(), new(sesv2.ListEmailIdentitiesInput))
+	for paginator.HasMorePages() {
+		ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+		page, err := paginator.NextPage(ctx)
+		cancel()
+
+		if err != nil {
+			resp.Error = err
+			return
+		}
+
+		for _, identity := range page.EmailIdentities {
+			resp.EmailIdentities = append(resp.EmailIdentities, &EmailIdentity{
+				Identity:           aws.StringValue(identity.IdentityName),
+				IdentityType:       string(identity.IdentityType),
+				VerifiedForSending: identity.SendingEnabled,
+				VerificationStatus: string(identity.VerificationStatus),
+			})
+		}
+	}
+
+	return
+}
+
+// DKIMRecords CNAME records to publish for Easy DKIM tokens of a domain
+func DKIMRecords(domain string, tokens []string) []*DNSRecord {
+	var records []*DNSRecord
+	for _, token := range tokens {
+		records = append(records, &DNSRecord{
+			Type:  "CNAME",
+			Name:  token + "._domainkey." + domain,
+			Value: token + dkimRecordSuffix,
+		})
+	}
+
+	return records
+}
+
+// setDKIM copies DKIM attributes, adding DNS records for domain identities
+func (i *EmailIdentity) setDKIM(attrs *sesv2types.DkimAttributes) {
+	if attrs == nil {
+		return
+	}
+
+	i.DKIMStatus = string(attrs.Status)
+	i.DKIMTokens = attrs.Tokens
+	if !strings.Contains(i.Identity, "@") {
+		i.DKIMRecords = DKIMRecords(i.Identity, attrs.Tokens)
+	}
+}
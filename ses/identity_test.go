@@ -0,0 +1,15 @@
+package ses
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDKIMRecords(t *testing.T) {
+	records := DKIMRecords("woodstock.club", []string{"abc123", "def456"})
+	assert.Equal(t, []*DNSRecord{
+		{Type: "CNAME", Name: "abc123._domainkey.woodstock.club", Value: "abc123.dkim.amazonses.com"},
+		{Type: "CNAME", Name: "def456._domainkey.woodstock.club", Value: "def456.dkim.amazonses.com"},
+	}, records)
+}
@@ -0,0 +1,418 @@
+package ses
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// ErrLocalUnsupported the operation has no local equivalent and is rejected instead of calling SES
+var ErrLocalUnsupported = errors.New("ses: not supported in local mode")
+
+// LocalOptions local development backend options
+type LocalOptions struct {
+	// SMTPAddr host:port of an SMTP server such as MailHog, empty captures emails in memory only
+	SMTPAddr string
+	// SMTPAuth optional SMTP authentication
+	SMTPAuth smtp.Auth
+	// TemplateDir optional directory to preload templates from, see SyncTemplatesOptions for the layout
+	TemplateDir string
+}
+
+// CapturedEmail email sent through the local backend
+type CapturedEmail struct {
+	MessageID  string
+	Sender     string
+	Recipients []string
+	Subject    string
+	HTML       string
+	Text       string
+	Template   string
+	Data       []byte
+}
+
+// localBackend renders and sends emails locally instead of through SES,
+// templates and the suppression list are kept in memory
+type localBackend struct {
+	opts       *LocalOptions
+	mu         sync.Mutex
+	templates  map[string]*CreateTemplateOptions
+	suppressed map[string]*SuppressedEmail
+	captured   []*CapturedEmail
+}
+
+// NewLocalService service initializer sending through SMTP, or capturing in memory, instead of SES, nil options capture in memory
+func NewLocalService(opts *LocalOptions) (*Service, error) {
+	if opts == nil {
+		opts = new(LocalOptions)
+	}
+
+	local := &localBackend{
+		opts:       opts,
+		templates:  map[string]*CreateTemplateOptions{},
+		suppressed: map[string]*SuppressedEmail{},
+	}
+
+	if opts.TemplateDir != "" {
+		templates, err := LoadTemplates(opts.TemplateDir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tmpl := range templates {
+			local.templates[tmpl.TemplateName] = tmpl
+		}
+	}
+
+	return &Service{
+		context: new(context),
+		local:   local,
+	}, nil
+}
+
+// CapturedEmails emails sent so far through the local backend, nil for SES services
+func (s *Service) CapturedEmails() []*CapturedEmail {
+	if s.local == nil {
+		return nil
+	}
+
+	s.local.mu.Lock()
+	defer s.local.mu.Unlock()
+	return append([]*CapturedEmail(nil), s.local.captured...)
+}
+
+// ResetCapturedEmails clears the captured emails
+func (s *Service) ResetCapturedEmails() {
+	if s.local == nil {
+		return
+	}
+
+	s.local.mu.Lock()
+	defer s.local.mu.Unlock()
+	s.local.captured = nil
+}
+
+// sendTemplated renders a stored template and sends it
+func (l *localBackend) sendTemplated(sender string, recipients, ccs, bccs []string, template string, data map[string]string, replyTo []string) (string, error) {
+	tmpl := l.getTemplate(template)
+	if tmpl.Error != nil {
+		return "", tmpl.Error
+	}
+
+	renderData := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		renderData[k] = v
+	}
+
+	rendered := RenderTemplate(&RenderTemplateOptions{Template: tmpl, Data: renderData, Strict: true})
+	if rendered.Error != nil {
+		return "", rendered.Error
+	}
+
+	b := newAddressedBuilder(sender, recipients, ccs, bccs, replyTo).
+		Subject(rendered.Subject).
+		HTML(rendered.HTML).
+		Text(rendered.Text)
+
+	return l.sendBuilder(b, &CapturedEmail{
+		Subject:  rendered.Subject,
+		HTML:     rendered.HTML,
+		Text:     rendered.Text,
+		Template: template,
+	})
+}
+
+// sendSimple builds and sends a simple email
+func (l *localBackend) sendSimple(opts *SendSimpleEmailOptions) (string, error) {
+	b := newAddressedBuilder(opts.Sender, opts.Recipients, opts.CCs, opts.BCCs, opts.ReplyTo).
+		Subject(opts.Subject).
+		HTML(opts.HTML).
+		Text(opts.Text)
+
+	if opts.ReturnPath != "" {
+		b.Header("Return-Path", "<"+opts.ReturnPath+">")
+	}
+
+	for k, v := range opts.Headers {
+		b.Header(k, v)
+	}
+
+	return l.sendBuilder(b, &CapturedEmail{
+		Subject: opts.Subject,
+		HTML:    opts.HTML,
+		Text:    opts.Text,
+	})
+}
+
+// sendRaw sends a raw message, taking sender and recipients from its headers when not given
+func (l *localBackend) sendRaw(opts *SendRawEmailOptions) (string, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(opts.Data))
+	if err != nil {
+		return "", err
+	}
+
+	sender := opts.Sender
+	if sender == "" {
+		sender = msg.Header.Get("From")
+	}
+
+	recipients := append(append(append([]string(nil), opts.Recipients...), opts.CCs...), opts.BCCs...)
+	if len(recipients) == 0 {
+		for _, header := range []string{"To", "Cc", "Bcc"} {
+			addresses, _ := msg.Header.AddressList(header)
+			for _, a := range addresses {
+				recipients = append(recipients, a.Address)
+			}
+		}
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	captured := &CapturedEmail{
+		MessageID: msg.Header.Get("Message-Id"),
+		Subject:   subject,
+	}
+
+	if captured.MessageID == "" {
+		captured.MessageID = messageID(addressOnly(sender))
+	}
+
+	return captured.MessageID, l.deliver(sender, recipients, opts.Data, captured)
+}
+
+// sendBuilder builds the message and delivers it to all of its recipients
+func (l *localBackend) sendBuilder(b *MessageBuilder, captured *CapturedEmail) (string, error) {
+	data, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	captured.MessageID = msg.Header.Get("Message-Id")
+	var recipients []string
+	for _, list := range [][]*mail.Address{b.to, b.cc, b.bcc} {
+		for _, a := range list {
+			recipients = append(recipients, a.Address)
+		}
+	}
+
+	return captured.MessageID, l.deliver(b.from.Address, recipients, data, captured)
+}
+
+// deliver sends data over SMTP when configured, and captures it
+func (l *localBackend) deliver(sender string, recipients []string, data []byte, captured *CapturedEmail) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("ses: message has no recipients")
+	}
+
+	from := addressOnly(sender)
+	for i, r := range recipients {
+		recipients[i] = addressOnly(r)
+	}
+
+	if l.opts.SMTPAddr != "" {
+		if err := smtp.SendMail(l.opts.SMTPAddr, l.opts.SMTPAuth, from, recipients, data); err != nil {
+			return err
+		}
+	}
+
+	captured.Sender = from
+	captured.Recipients = recipients
+	captured.Data = data
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.captured = append(l.captured, captured)
+	return nil
+}
+
+// putTemplate creates or updates a stored template
+func (l *localBackend) putTemplate(opts *CreateTemplateOptions, create bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, exists := l.templates[opts.TemplateName]
+	if create && exists {
+		return fmt.Errorf("ses: template %s already exists", opts.TemplateName)
+	}
+
+	if !create && !exists {
+		return fmt.Errorf("ses: template %s does not exist", opts.TemplateName)
+	}
+
+	tmpl := *opts
+	l.templates[opts.TemplateName] = &tmpl
+	return nil
+}
+
+// deleteTemplate deletes a stored template
+func (l *localBackend) deleteTemplate(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.templates[name]; !ok {
+		return fmt.Errorf("ses: template %s does not exist", name)
+	}
+
+	delete(l.templates, name)
+	return nil
+}
+
+// listTemplates lists stored template names in order
+func (l *localBackend) listTemplates() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	names := make([]string, 0, len(l.templates))
+	for name := range l.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getTemplate gets a stored template
+func (l *localBackend) getTemplate(name string) *GetTemplateResponse {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tmpl, ok := l.templates[name]
+	if !ok {
+		return &GetTemplateResponse{Error: fmt.Errorf("ses: template %s does not exist", name)}
+	}
+
+	return &GetTemplateResponse{
+		TemplateName: aws.String(tmpl.TemplateName),
+		SubjectPart:  aws.String(tmpl.Subject),
+		HtmlPart:     tmpl.HTML,
+		TextPart:     tmpl.Text,
+	}
+}
+
+// lookupSuppressed gets a suppressed address, nil when not suppressed
+func (l *localBackend) lookupSuppressed(email string) *SuppressedEmail {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	destination, ok := l.suppressed[strings.ToLower(email)]
+	if !ok {
+		return nil
+	}
+
+	copied := *destination
+	return &copied
+}
+
+// putSuppressed adds or updates a suppressed address
+func (l *localBackend) putSuppressed(email string, reason SuppressionReason) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.suppressed[strings.ToLower(email)] = &SuppressedEmail{
+		Email:          email,
+		Reason:         string(reason),
+		LastUpdateTime: time.Now(),
+	}
+}
+
+// deleteSuppressed removes a suppressed address
+func (l *localBackend) deleteSuppressed(email string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := strings.ToLower(email)
+	if _, ok := l.suppressed[key]; !ok {
+		return fmt.Errorf("ses: %s is not in the suppression list", email)
+	}
+
+	delete(l.suppressed, key)
+	return nil
+}
+
+// listSuppressed lists suppressed addresses matching the filters in address order
+func (l *localBackend) listSuppressed(opts *ListAllSuppressedDestinationsOptions) []*SuppressedEmail {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := []*SuppressedEmail{}
+	for _, destination := range l.suppressed {
+		if len(opts.Reasons) > 0 && !hasReason(opts.Reasons, destination.Reason) {
+			continue
+		}
+
+		if !opts.StartDate.IsZero() && destination.LastUpdateTime.Before(opts.StartDate) {
+			continue
+		}
+
+		if !opts.EndDate.IsZero() && destination.LastUpdateTime.After(opts.EndDate) {
+			continue
+		}
+
+		copied := *destination
+		result = append(result, &copied)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Email < result[j].Email })
+	return result
+}
+
+// hasReason whether reasons contains reason
+func hasReason(reasons []SuppressionReason, reason string) bool {
+	for _, r := range reasons {
+		if string(r) == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newAddressedBuilder message builder with addresses in "Name <address>" or bare form
+func newAddressedBuilder(sender string, recipients, ccs, bccs, replyTo []string) *MessageBuilder {
+	b := NewMessageBuilder().From(addressParts(sender))
+	for _, r := range recipients {
+		b.To(addressParts(r))
+	}
+
+	for _, r := range ccs {
+		b.Cc(addressParts(r))
+	}
+
+	for _, r := range bccs {
+		b.Bcc(addressParts(r))
+	}
+
+	for _, r := range replyTo {
+		b.ReplyTo(addressParts(r))
+	}
+
+	return b
+}
+
+// addressParts splits an address into display name and address
+func addressParts(address string) (string, string) {
+	a, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", address
+	}
+
+	return a.Name, a.Address
+}
+
+// addressOnly strips the display name of an address
+func addressOnly(address string) string {
+	_, a := addressParts(address)
+	return a
+}
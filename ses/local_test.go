@@ -0,0 +1,204 @@
+package ses
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveSMTP accepts a single SMTP session and sends the received message data to the returned channel
+func serveSMTP(t *testing.T) (string, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO", "MAIL", "RCPT":
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, _ := tp.ReadDotBytes()
+				received <- string(data)
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), received
+}
+
+func TestLocalServiceTemplatedEmail(t *testing.T) {
+	svc, err := NewLocalService(new(LocalOptions))
+	assert.NoError(t, err)
+
+	assert.NoError(t, svc.CreateTemplate(&CreateTemplateOptions{
+		TemplateName: "welcome",
+		Subject:      "Welcome {{name}}",
+		HTML:         aws.String("<h1>Hello {{name}}</h1>"),
+	}).Error)
+	assert.Error(t, svc.CreateTemplate(&CreateTemplateOptions{TemplateName: "welcome"}).Error)
+	assert.Equal(t, []string{"welcome"}, svc.ListTemplates(new(ListTemplatesOptions)).Templates)
+
+	resp := svc.SendEmail(&SendEmailOptions{
+		Sender:       "Woodstock <contact@woodstock.club>",
+		Recipients:   []string{"min@woodstock.club"},
+		BCCs:         []string{"audit@woodstock.club"},
+		Template:     "welcome",
+		TemplateData: map[string]string{"name": "Min"},
+	})
+	assert.NoError(t, resp.Error)
+
+	captured := svc.CapturedEmails()
+	assert.Len(t, captured, 1)
+	assert.Equal(t, "contact@woodstock.club", captured[0].Sender)
+	assert.Equal(t, []string{"min@woodstock.club", "audit@woodstock.club"}, captured[0].Recipients)
+	assert.Equal(t, "Welcome Min", captured[0].Subject)
+	assert.Equal(t, "<h1>Hello Min</h1>", captured[0].HTML)
+	assert.Equal(t, "welcome", captured[0].Template)
+	assert.NotEmpty(t, captured[0].MessageID)
+
+	// missing data fails like an SES rendering failure
+	resp = svc.SendEmail(&SendEmailOptions{
+		Sender:     "contact@woodstock.club",
+		Recipients: []string{"min@woodstock.club"},
+		Template:   "welcome",
+	})
+	assert.Error(t, resp.Error)
+
+	svc.ResetCapturedEmails()
+	assert.Empty(t, svc.CapturedEmails())
+}
+
+func TestLocalServiceSMTP(t *testing.T) {
+	addr, received := serveSMTP(t)
+	svc, err := NewLocalService(&LocalOptions{SMTPAddr: addr})
+	assert.NoError(t, err)
+
+	resp := svc.SendSimpleEmail(&SendSimpleEmailOptions{
+		Sender:     "contact@woodstock.club",
+		Recipients: []string{"min@woodstock.club"},
+		Subject:    "hello",
+		Text:       "hello from local",
+	})
+	assert.NoError(t, resp.Error)
+	assert.NotEmpty(t, resp.MessageID)
+
+	data := <-received
+	assert.Contains(t, data, "Subject: hello")
+	assert.Contains(t, data, "hello from local")
+}
+
+func TestLocalServiceRawEmail(t *testing.T) {
+	svc, err := NewLocalService(new(LocalOptions))
+	assert.NoError(t, err)
+
+	opts, err := NewMessageBuilder().
+		From("", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Subject("請求書").
+		Text("see attachment").
+		RawEmailOptions()
+	assert.NoError(t, err)
+
+	// recipients are read from the headers when not given
+	opts.Recipients, opts.Sender = nil, ""
+	resp := svc.SendRawEmail(opts)
+	assert.NoError(t, resp.Error)
+
+	captured := svc.CapturedEmails()
+	assert.Len(t, captured, 1)
+	assert.Equal(t, "contact@woodstock.club", captured[0].Sender)
+	assert.Equal(t, []string{"min@woodstock.club"}, captured[0].Recipients)
+	assert.Equal(t, "請求書", captured[0].Subject)
+	assert.Equal(t, resp.MessageID, captured[0].MessageID)
+}
+
+func TestLocalServiceSuppression(t *testing.T) {
+	svc, err := NewLocalService(new(LocalOptions))
+	assert.NoError(t, err)
+
+	resp := svc.SuppressHardBounces(&Event{
+		EventType: EventTypeBounce,
+		Bounce: &EventBounce{
+			BounceType:        BounceTypePermanent,
+			BouncedRecipients: []EventBouncedRecipient{{EmailAddress: "Bounced@woodstock.club"}},
+		},
+	})
+	assert.NoError(t, resp.Error)
+
+	lookup := svc.LookupSuppressedDestination(&LookupSuppressedDestinationOptions{Email: "bounced@woodstock.club"})
+	assert.NoError(t, lookup.Error)
+	assert.True(t, lookup.Suppressed)
+	assert.Equal(t, string(SuppressionReasonBounce), lookup.Destination.Reason)
+
+	assert.NoError(t, svc.PutSuppressedDestination(&PutSuppressedDestinationOptions{
+		Email:  "complained@woodstock.club",
+		Reason: SuppressionReasonComplaint,
+	}).Error)
+
+	list := svc.ListAllSuppressedDestinations(&ListAllSuppressedDestinationsOptions{
+		Reasons: []SuppressionReason{SuppressionReasonComplaint},
+	})
+	assert.NoError(t, list.Error)
+	assert.Len(t, list.SuppressedEmailList, 1)
+	assert.Equal(t, "complained@woodstock.club", list.SuppressedEmailList[0].Email)
+
+	opts := &SendEmailOptions{Recipients: []string{"Bounced <bounced@woodstock.club>", "min@woodstock.club"}}
+	filtered := svc.FilterSuppressedRecipients(opts)
+	assert.NoError(t, filtered.Error)
+	assert.Equal(t, []string{"min@woodstock.club"}, opts.Recipients)
+
+	assert.NoError(t, svc.DeleteSuppressedDestination(&DeleteSuppressedDestinationOptions{Email: "bounced@woodstock.club"}).Error)
+	assert.Error(t, svc.DeleteSuppressedDestination(&DeleteSuppressedDestinationOptions{Email: "bounced@woodstock.club"}).Error)
+	assert.False(t, svc.LookupSuppressedDestination(&LookupSuppressedDestinationOptions{Email: "bounced@woodstock.club"}).Suppressed)
+}
+
+func TestLocalServiceNilOptions(t *testing.T) {
+	svc, err := NewLocalService(nil)
+	assert.NoError(t, err)
+
+	resp := svc.SendSimpleEmail(&SendSimpleEmailOptions{
+		Sender:     "contact@woodstock.club",
+		Recipients: []string{"min@woodstock.club"},
+		Subject:    "hello",
+		Text:       "hello",
+	})
+	assert.NoError(t, resp.Error)
+	assert.Len(t, svc.CapturedEmails(), 1)
+}
+
+func TestLocalServiceUnsupported(t *testing.T) {
+	svc, err := NewLocalService(new(LocalOptions))
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, svc.GetSendQuota(new(GetSendQuotaOptions)).Error, ErrLocalUnsupported)
+	assert.ErrorIs(t, svc.ListConfigurationSets(new(ListConfigurationSetsOptions)).Error, ErrLocalUnsupported)
+	assert.ErrorIs(t, svc.ListEmailIdentities(new(ListEmailIdentitiesOptions)).Error, ErrLocalUnsupported)
+}
@@ -0,0 +1,419 @@
+package ses
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MaxRawMessageSize SES rejects raw messages larger than 10MB, attachments included
+const MaxRawMessageSize = 10 * 1024 * 1024
+
+// ErrMessageTooLarge the built message exceeds MaxRawMessageSize
+var ErrMessageTooLarge = errors.New("ses: message exceeds the 10MB size limit")
+
+// base64LineLength RFC 2045 limits encoded lines to 76 characters
+const base64LineLength = 76
+
+// MessageBuilder builds MIME messages for SendRawEmail, supporting
+// multipart/alternative bodies, attachments and inline CID images
+type MessageBuilder struct {
+	from        *mail.Address
+	to          []*mail.Address
+	cc          []*mail.Address
+	bcc         []*mail.Address
+	replyTo     []*mail.Address
+	subject     string
+	html        string
+	text        string
+	headers     [][2]string
+	attachments []*attachment
+	inlines     []*attachment
+	err         error
+}
+
+// attachment file attached to, or embedded inline in, the message
+type attachment struct {
+	filename    string
+	contentType string
+	contentID   string
+	data        []byte
+}
+
+// mimePart encoded MIME part
+type mimePart struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// NewMessageBuilder message builder initializer
+func NewMessageBuilder() *MessageBuilder {
+	return new(MessageBuilder)
+}
+
+// From set sender, name may be empty or non-ASCII
+func (b *MessageBuilder) From(name, address string) *MessageBuilder {
+	b.from = &mail.Address{Name: name, Address: address}
+	return b
+}
+
+// To add recipient
+func (b *MessageBuilder) To(name, address string) *MessageBuilder {
+	b.to = append(b.to, &mail.Address{Name: name, Address: address})
+	return b
+}
+
+// Cc add carbon copy recipient
+func (b *MessageBuilder) Cc(name, address string) *MessageBuilder {
+	b.cc = append(b.cc, &mail.Address{Name: name, Address: address})
+	return b
+}
+
+// Bcc add blind carbon copy recipient, not written to the message headers
+func (b *MessageBuilder) Bcc(name, address string) *MessageBuilder {
+	b.bcc = append(b.bcc, &mail.Address{Name: name, Address: address})
+	return b
+}
+
+// ReplyTo add Reply-To address
+func (b *MessageBuilder) ReplyTo(name, address string) *MessageBuilder {
+	b.replyTo = append(b.replyTo, &mail.Address{Name: name, Address: address})
+	return b
+}
+
+// Subject set subject, non-ASCII subjects are RFC 2047 encoded
+func (b *MessageBuilder) Subject(subject string) *MessageBuilder {
+	b.subject = subject
+	return b
+}
+
+// HTML set HTML body
+func (b *MessageBuilder) HTML(html string) *MessageBuilder {
+	b.html = html
+	return b
+}
+
+// Text set plain text body
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	b.text = text
+	return b
+}
+
+// Header add a custom header, e.g. List-Unsubscribe
+func (b *MessageBuilder) Header(name, value string) *MessageBuilder {
+	if strings.ContainsAny(name, "\r\n:") || strings.ContainsAny(value, "\r\n") {
+		b.setErr(fmt.Errorf("ses: invalid header %q", name))
+		return b
+	}
+
+	b.headers = append(b.headers, [2]string{textproto.CanonicalMIMEHeaderKey(name), value})
+	return b
+}
+
+// Attach add an attachment read from r, contentType is guessed from filename when empty
+func (b *MessageBuilder) Attach(filename, contentType string, r io.Reader) *MessageBuilder {
+	if a := b.readAttachment(filename, contentType, r); a != nil {
+		b.attachments = append(b.attachments, a)
+	}
+	return b
+}
+
+// AttachFile add an attachment read from path
+func (b *MessageBuilder) AttachFile(path string) *MessageBuilder {
+	f, err := os.Open(path)
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	defer f.Close()
+
+	return b.Attach(filepath.Base(path), "", f)
+}
+
+// Inline add an inline image read from r, referenced from HTML as <img src="cid:contentID">
+func (b *MessageBuilder) Inline(contentID, filename, contentType string, r io.Reader) *MessageBuilder {
+	if a := b.readAttachment(filename, contentType, r); a != nil {
+		a.contentID = contentID
+		b.inlines = append(b.inlines, a)
+	}
+	return b
+}
+
+// InlineFile add an inline image read from path
+func (b *MessageBuilder) InlineFile(contentID, path string) *MessageBuilder {
+	f, err := os.Open(path)
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	defer f.Close()
+
+	return b.Inline(contentID, filepath.Base(path), "", f)
+}
+
+// Build encodes the message, failing with ErrMessageTooLarge above MaxRawMessageSize
+func (b *MessageBuilder) Build() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.from == nil {
+		return nil, errors.New("ses: message has no sender")
+	}
+
+	if len(b.to)+len(b.cc)+len(b.bcc) == 0 {
+		return nil, errors.New("ses: message has no recipients")
+	}
+
+	root, err := b.rootPart()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", b.from.String())
+	writeHeader(&buf, "To", joinAddresses(b.to))
+	writeHeader(&buf, "Cc", joinAddresses(b.cc))
+	writeHeader(&buf, "Reply-To", joinAddresses(b.replyTo))
+	writeHeader(&buf, "Subject", encodeHeader(b.subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-Id", messageID(b.from.Address))
+	writeHeader(&buf, "Mime-Version", "1.0")
+	for _, h := range b.headers {
+		writeHeader(&buf, h[0], encodeHeader(h[1]))
+	}
+	writeHeader(&buf, "Content-Type", root.header.Get("Content-Type"))
+	writeHeader(&buf, "Content-Transfer-Encoding", root.header.Get("Content-Transfer-Encoding"))
+	buf.WriteString("\r\n")
+	buf.Write(root.body)
+
+	if buf.Len() > MaxRawMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RawEmailOptions builds the message into options for SendRawEmail, Bcc recipients included
+func (b *MessageBuilder) RawEmailOptions() (*SendRawEmailOptions, error) {
+	data, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendRawEmailOptions{
+		Sender:     b.from.String(),
+		Recipients: addressStrings(b.to),
+		CCs:        addressStrings(b.cc),
+		BCCs:       addressStrings(b.bcc),
+		Data:       data,
+	}, nil
+}
+
+// rootPart nests the body parts as mixed(related(alternative(text, html), inlines), attachments)
+func (b *MessageBuilder) rootPart() (*mimePart, error) {
+	var bodies []*mimePart
+	if b.text != "" || b.html == "" {
+		bodies = append(bodies, textPart("text/plain", b.text))
+	}
+
+	if b.html != "" {
+		bodies = append(bodies, textPart("text/html", b.html))
+	}
+
+	root := bodies[0]
+	if len(bodies) > 1 {
+		var err error
+		if root, err = multipartPart("alternative", bodies); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.inlines) > 0 {
+		parts := []*mimePart{root}
+		for _, a := range b.inlines {
+			parts = append(parts, a.part("inline"))
+		}
+
+		var err error
+		if root, err = multipartPart("related", parts); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.attachments) > 0 {
+		parts := []*mimePart{root}
+		for _, a := range b.attachments {
+			parts = append(parts, a.part("attachment"))
+		}
+
+		var err error
+		if root, err = multipartPart("mixed", parts); err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// readAttachment reads r, stopping as soon as the size limit is exceeded
+func (b *MessageBuilder) readAttachment(filename, contentType string, r io.Reader) *attachment {
+	data, err := io.ReadAll(io.LimitReader(r, MaxRawMessageSize+1))
+	if err != nil {
+		b.setErr(err)
+		return nil
+	}
+
+	if len(data) > MaxRawMessageSize {
+		b.setErr(ErrMessageTooLarge)
+		return nil
+	}
+
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// guessed types may carry parameters such as charset, which are kept next to the name
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		b.setErr(fmt.Errorf("ses: invalid content type %q of %s: %w", contentType, filename, err))
+		return nil
+	}
+	params["name"] = filename
+
+	return &attachment{
+		filename:    filename,
+		contentType: mime.FormatMediaType(mediaType, params),
+		data:        data,
+	}
+}
+
+// setErr keeps the first error, reported by Build
+func (b *MessageBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// part encodes the attachment as a base64 MIME part
+func (a *attachment) part(disposition string) *mimePart {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", a.contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.filename}))
+	if a.contentID != "" {
+		header.Set("Content-Id", "<"+a.contentID+">")
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(a.data)
+	var body bytes.Buffer
+	for len(encoded) > base64LineLength {
+		body.WriteString(encoded[:base64LineLength])
+		body.WriteString("\r\n")
+		encoded = encoded[base64LineLength:]
+	}
+	body.WriteString(encoded)
+
+	return &mimePart{header: header, body: body.Bytes()}
+}
+
+// textPart encodes a UTF-8 text body as a quoted-printable MIME part
+func textPart(contentType, text string) *mimePart {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+"; charset=UTF-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	var body bytes.Buffer
+	w := quotedprintable.NewWriter(&body)
+	w.Write([]byte(text))
+	w.Close()
+
+	return &mimePart{header: header, body: body.Bytes()}
+}
+
+// multipartPart wraps parts in a multipart/subtype part
+func multipartPart(subtype string, parts []*mimePart) (*mimePart, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, p := range parts {
+		pw, err := w.CreatePart(p.header)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = pw.Write(p.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", "multipart/"+subtype+"; boundary="+w.Boundary())
+	header.Set("Content-Transfer-Encoding", "7bit")
+	return &mimePart{header: header, body: body.Bytes()}, nil
+}
+
+// writeHeader writes a header line, skipping empty values
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	if value == "" {
+		return
+	}
+
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	// fold between encoded-words to keep lines short
+	buf.WriteString(strings.ReplaceAll(value, "?= =?", "?=\r\n =?"))
+	buf.WriteString("\r\n")
+}
+
+// encodeHeader RFC 2047 encodes non-ASCII header values
+func encodeHeader(value string) string {
+	return mime.BEncoding.Encode("UTF-8", value)
+}
+
+// joinAddresses formats addresses for an address list header
+func joinAddresses(addresses []*mail.Address) string {
+	return strings.Join(addressStrings(addresses), ", ")
+}
+
+// addressStrings formats addresses, RFC 2047 encoding non-ASCII display names
+func addressStrings(addresses []*mail.Address) []string {
+	var result []string
+	for _, a := range addresses {
+		result = append(result, a.String())
+	}
+
+	return result
+}
+
+// messageID generates a unique Message-Id in the sender domain
+func messageID(sender string) string {
+	domain := "localhost"
+	if i := strings.LastIndex(sender, "@"); i >= 0 {
+		domain = sender[i+1:]
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(id), domain)
+}
@@ -0,0 +1,141 @@
+package ses
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readParts flattens a multipart tree into leaf parts
+func readParts(t *testing.T, contentType string, body io.Reader) []*multipart.Part {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	assert.NoError(t, err)
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil
+	}
+
+	var leaves []*multipart.Part
+	r := multipart.NewReader(body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return leaves
+		}
+		assert.NoError(t, err)
+
+		if strings.HasPrefix(p.Header.Get("Content-Type"), "multipart/") {
+			leaves = append(leaves, readParts(t, p.Header.Get("Content-Type"), p)...)
+			continue
+		}
+
+		var body io.Reader = p
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			body = base64.NewDecoder(base64.StdEncoding, p)
+		}
+
+		data, err := io.ReadAll(body)
+		assert.NoError(t, err)
+		p.Header.Set("X-Test-Body", string(data))
+		leaves = append(leaves, p)
+	}
+}
+
+func TestMessageBuilder(t *testing.T) {
+	data, err := NewMessageBuilder().
+		From("ウッドストック", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Bcc("", "audit@woodstock.club").
+		Subject("ご請求書のお知らせ").
+		Text("請求書を添付しました").
+		HTML(`<p>請求書を添付しました</p><img src="cid:logo">`).
+		Header("List-Unsubscribe", "<mailto:unsubscribe@woodstock.club>").
+		Inline("logo", "logo.png", "", bytes.NewReader([]byte("png-data"))).
+		Attach("請求書.csv", "", strings.NewReader("id,amount\n1,1200\n")).
+		Build()
+	assert.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "ご請求書のお知らせ", subject)
+
+	from, err := msg.Header.AddressList("From")
+	assert.NoError(t, err)
+	assert.Equal(t, "ウッドストック", from[0].Name)
+	assert.Empty(t, msg.Header.Get("Bcc"))
+	assert.Equal(t, "<mailto:unsubscribe@woodstock.club>", msg.Header.Get("List-Unsubscribe"))
+
+	parts := readParts(t, msg.Header.Get("Content-Type"), msg.Body)
+	assert.Len(t, parts, 4)
+	assert.Equal(t, "請求書を添付しました", parts[0].Header.Get("X-Test-Body"))
+	assert.Contains(t, parts[1].Header.Get("Content-Type"), "text/html")
+	assert.Equal(t, "<logo>", parts[2].Header.Get("Content-Id"))
+	assert.Equal(t, "png-data", parts[2].Header.Get("X-Test-Body"))
+	assert.Equal(t, "請求書.csv", parts[3].FileName())
+	mediaType, params, err := mime.ParseMediaType(parts[3].Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv", mediaType)
+	assert.Equal(t, "utf-8", params["charset"])
+	assert.Equal(t, "請求書.csv", params["name"])
+	assert.Equal(t, "id,amount\n1,1200\n", parts[3].Header.Get("X-Test-Body"))
+}
+
+func TestMessageBuilderRawEmailOptions(t *testing.T) {
+	opts, err := NewMessageBuilder().
+		From("", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Bcc("", "audit@woodstock.club").
+		Text("hello").
+		RawEmailOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"<min@woodstock.club>"}, opts.Recipients)
+	assert.Equal(t, []string{"<audit@woodstock.club>"}, opts.BCCs)
+	assert.NotEmpty(t, opts.Data)
+}
+
+func TestMessageBuilderErrors(t *testing.T) {
+	_, err := NewMessageBuilder().To("", "min@woodstock.club").Build()
+	assert.Error(t, err)
+
+	_, err = NewMessageBuilder().From("", "contact@woodstock.club").Build()
+	assert.Error(t, err)
+
+	_, err = NewMessageBuilder().
+		From("", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Header("X-Injected", "a\r\nBcc: victim@example.com").
+		Build()
+	assert.Error(t, err)
+
+	_, err = NewMessageBuilder().
+		From("", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Attach("large.bin", "", bytes.NewReader(make([]byte, MaxRawMessageSize+1))).
+		Build()
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+
+	_, err = NewMessageBuilder().
+		From("", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Attach("report.csv", "text/csv; charset", strings.NewReader("id\n")).
+		Build()
+	assert.Error(t, err)
+
+	// base64 overhead pushes a just-under-limit attachment over the limit
+	_, err = NewMessageBuilder().
+		From("", "contact@woodstock.club").
+		To("", "min@woodstock.club").
+		Attach("large.bin", "", bytes.NewReader(make([]byte, MaxRawMessageSize-1024))).
+		Build()
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+}
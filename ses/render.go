@@ -0,0 +1,414 @@
+package ses
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// RenderTemplateOptions render template options
+type RenderTemplateOptions struct {
+	Template *GetTemplateResponse
+	Data     map[string]interface{}
+	// Strict fails rendering when variables are missing
+	Strict bool
+}
+
+// RenderTemplateResponse rendered template parts, with variables missing from, or unused in, Data
+type RenderTemplateResponse struct {
+	Subject          string
+	HTML             string
+	Text             string
+	MissingVariables []string
+	UnusedVariables  []string
+	Error            error
+}
+
+// templateNode parsed template node, one of text, variable or block
+type templateNode struct {
+	text     string
+	path     string
+	escape   bool
+	helper   string
+	children []*templateNode
+	inverse  []*templateNode
+}
+
+// renderScope data scope, nested by #each and #with
+type renderScope struct {
+	value  interface{}
+	parent *renderScope
+	// prefix path of value from the root data, for reporting missing variables
+	prefix string
+	index  int
+	first  bool
+	last   bool
+}
+
+// renderer renders a parsed template and records missing variables
+type renderer struct {
+	missing map[string]struct{}
+}
+
+// RenderTemplate renders a template locally with the subset of Handlebars SES supports:
+// {{var}}, {{nested.var}}, {{{unescaped}}}, {{#if}}, {{#unless}}, {{else}}, {{#each}} and {{#with}}.
+// Variables are HTML escaped in the HTML part only
+func RenderTemplate(opts *RenderTemplateOptions) (resp *RenderTemplateResponse) {
+	resp = new(RenderTemplateResponse)
+	if opts.Template == nil {
+		resp.Error = fmt.Errorf("ses: template is required")
+		return
+	}
+
+	parts := []struct {
+		source *string
+		out    *string
+		html   bool
+	}{
+		{opts.Template.SubjectPart, &resp.Subject, false},
+		{opts.Template.HtmlPart, &resp.HTML, true},
+		{opts.Template.TextPart, &resp.Text, false},
+	}
+
+	r := &renderer{missing: map[string]struct{}{}}
+	used := map[string]struct{}{}
+	for _, part := range parts {
+		nodes, err := parseTemplate(aws.StringValue(part.source))
+		if err != nil {
+			resp.Error = err
+			return
+		}
+
+		collectRootVariables(nodes, 0, used)
+
+		var b strings.Builder
+		r.render(&b, nodes, &renderScope{value: opts.Data}, part.html)
+		*part.out = b.String()
+	}
+
+	resp.MissingVariables = sortedKeys(r.missing)
+	for k := range opts.Data {
+		if _, ok := used[k]; !ok {
+			resp.UnusedVariables = append(resp.UnusedVariables, k)
+		}
+	}
+	sort.Strings(resp.UnusedVariables)
+
+	if opts.Strict && len(resp.MissingVariables) > 0 {
+		resp.Error = fmt.Errorf("ses: missing template variables: %s", strings.Join(resp.MissingVariables, ", "))
+	}
+
+	return
+}
+
+// TemplateVariables lists the root level variables referenced by a template source
+func TemplateVariables(source string) ([]string, error) {
+	nodes, err := parseTemplate(source)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]struct{}{}
+	collectRootVariables(nodes, 0, used)
+	return sortedKeys(used), nil
+}
+
+// parseTemplate parses a template source into nodes
+func parseTemplate(source string) ([]*templateNode, error) {
+	root := &templateNode{}
+	stack := []*templateNode{root}
+	inverse := []bool{false}
+
+	appendNode := func(n *templateNode) {
+		top := stack[len(stack)-1]
+		if inverse[len(inverse)-1] {
+			top.inverse = append(top.inverse, n)
+		} else {
+			top.children = append(top.children, n)
+		}
+	}
+
+	for len(source) > 0 {
+		start := strings.Index(source, "{{")
+		if start < 0 {
+			appendNode(&templateNode{text: source})
+			break
+		}
+
+		if start > 0 {
+			appendNode(&templateNode{text: source[:start]})
+		}
+		source = source[start:]
+
+		open, closing, escape := "{{", "}}", true
+		if strings.HasPrefix(source, "{{{") {
+			open, closing, escape = "{{{", "}}}", false
+		}
+
+		end := strings.Index(source, closing)
+		if end < 0 {
+			return nil, fmt.Errorf("ses: unclosed tag %q", source)
+		}
+
+		tag := strings.TrimSpace(source[len(open):end])
+		source = source[end+len(closing):]
+
+		switch {
+		case strings.HasPrefix(tag, "!"):
+			// comment
+		case strings.HasPrefix(tag, "#"):
+			fields := strings.Fields(tag[1:])
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("ses: invalid block {{%s}}", tag)
+			}
+
+			switch fields[0] {
+			case "if", "unless", "each", "with":
+			default:
+				return nil, fmt.Errorf("ses: unsupported helper %q", fields[0])
+			}
+
+			block := &templateNode{helper: fields[0], path: fields[1]}
+			appendNode(block)
+			stack = append(stack, block)
+			inverse = append(inverse, false)
+		case strings.HasPrefix(tag, "/"):
+			if len(stack) == 1 || stack[len(stack)-1].helper != strings.TrimSpace(tag[1:]) {
+				return nil, fmt.Errorf("ses: unexpected {{%s}}", tag)
+			}
+
+			stack = stack[:len(stack)-1]
+			inverse = inverse[:len(inverse)-1]
+		case tag == "else":
+			if len(stack) == 1 || inverse[len(inverse)-1] {
+				return nil, fmt.Errorf("ses: unexpected {{else}}")
+			}
+
+			inverse[len(inverse)-1] = true
+		default:
+			if tag == "" || strings.ContainsAny(tag, " \t\n") {
+				return nil, fmt.Errorf("ses: invalid variable {{%s}}", tag)
+			}
+
+			appendNode(&templateNode{path: tag, escape: escape})
+		}
+	}
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("ses: unclosed block {{#%s}}", stack[len(stack)-1].helper)
+	}
+
+	return root.children, nil
+}
+
+// collectRootVariables records the root data keys referenced by nodes, depth counts nested scopes
+func collectRootVariables(nodes []*templateNode, depth int, used map[string]struct{}) {
+	for _, n := range nodes {
+		if n.path != "" {
+			path, d := n.path, depth
+			for strings.HasPrefix(path, "../") {
+				path, d = path[3:], d-1
+			}
+
+			if path == "this" || path == "." {
+				path = ""
+			}
+
+			path = strings.TrimPrefix(path, "this.")
+			if d <= 0 && path != "" && !strings.HasPrefix(path, "@") {
+				used[strings.SplitN(path, ".", 2)[0]] = struct{}{}
+			}
+		}
+
+		childDepth := depth
+		if n.helper == "each" || n.helper == "with" {
+			childDepth++
+		}
+
+		collectRootVariables(n.children, childDepth, used)
+		collectRootVariables(n.inverse, depth, used)
+	}
+}
+
+// render writes nodes evaluated in scope
+func (r *renderer) render(b *strings.Builder, nodes []*templateNode, scope *renderScope, escape bool) {
+	for _, n := range nodes {
+		switch {
+		case n.helper == "":
+			if n.path == "" {
+				b.WriteString(n.text)
+				continue
+			}
+
+			value, ok := r.lookup(scope, n.path, true)
+			if !ok || value == nil {
+				continue
+			}
+
+			s := fmt.Sprint(value)
+			if escape && n.escape {
+				s = html.EscapeString(s)
+			}
+			b.WriteString(s)
+		case n.helper == "if" || n.helper == "unless":
+			value, _ := r.lookup(scope, n.path, false)
+			if truthy(value) == (n.helper == "if") {
+				r.render(b, n.children, scope, escape)
+			} else {
+				r.render(b, n.inverse, scope, escape)
+			}
+		case n.helper == "with":
+			value, _ := r.lookup(scope, n.path, true)
+			if !truthy(value) {
+				r.render(b, n.inverse, scope, escape)
+				continue
+			}
+
+			r.render(b, n.children, &renderScope{value: value, parent: scope, prefix: joinPath(scope.prefix, n.path)}, escape)
+		case n.helper == "each":
+			value, _ := r.lookup(scope, n.path, true)
+			items := listItems(value)
+			if len(items) == 0 {
+				r.render(b, n.inverse, scope, escape)
+				continue
+			}
+
+			for i, item := range items {
+				r.render(b, n.children, &renderScope{
+					value:  item,
+					parent: scope,
+					prefix: joinPath(scope.prefix, n.path),
+					index:  i,
+					first:  i == 0,
+					last:   i == len(items)-1,
+				}, escape)
+			}
+		}
+	}
+}
+
+// lookup resolves a variable path in scope, recording it as missing when report is set
+func (r *renderer) lookup(scope *renderScope, path string, report bool) (interface{}, bool) {
+	for strings.HasPrefix(path, "../") && scope.parent != nil {
+		path, scope = path[3:], scope.parent
+	}
+
+	switch path {
+	case "this", ".":
+		return scope.value, true
+	case "@index":
+		return scope.index, true
+	case "@first":
+		return scope.first, true
+	case "@last":
+		return scope.last, true
+	}
+
+	value, ok := scope.value, true
+	for _, key := range strings.Split(strings.TrimPrefix(path, "this."), ".") {
+		if value, ok = field(value, key); !ok {
+			break
+		}
+	}
+
+	if !ok && report {
+		r.missing[joinPath(scope.prefix, strings.TrimPrefix(path, "this."))] = struct{}{}
+	}
+
+	return value, ok
+}
+
+// field gets key from a string keyed map or a struct
+func field(value interface{}, key string) (interface{}, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+
+		fv := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !fv.IsValid() {
+			return nil, false
+		}
+		return fv.Interface(), true
+	case reflect.Struct:
+		fv := v.FieldByName(key)
+		if !fv.IsValid() || !fv.CanInterface() {
+			return nil, false
+		}
+		return fv.Interface(), true
+	}
+
+	return nil, false
+}
+
+// listItems returns the elements of a slice or array
+func listItems(value interface{}) []interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+
+	return items
+}
+
+// truthy follows Handlebars: nil, false, "", 0 and empty lists are false
+func truthy(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0
+	case reflect.Ptr, reflect.Interface:
+		return !v.IsNil()
+	}
+
+	return true
+}
+
+// joinPath joins a scope prefix and a path
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+
+	return prefix + "." + path
+}
+
+// sortedKeys sorted keys of a set
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
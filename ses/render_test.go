@@ -0,0 +1,96 @@
+package ses
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := &GetTemplateResponse{
+		SubjectPart: aws.String("{{name}}様 ご注文ありがとうございます"),
+		HtmlPart: aws.String(`<h1>{{name}}</h1>{{#if vip}}<p>VIP</p>{{else}}<p>member</p>{{/if}}` +
+			`<ul>{{#each items}}<li>{{@index}}:{{title}} {{../currency}}{{price}}</li>{{/each}}</ul>{{{footer}}}`),
+		TextPart: aws.String("{{#unless vip}}upgrade{{/unless}} {{#with address}}{{city}}{{/with}}"),
+	}
+
+	resp := RenderTemplate(&RenderTemplateOptions{
+		Template: tmpl,
+		Data: map[string]interface{}{
+			"name":     "<Taro>",
+			"vip":      false,
+			"currency": "¥",
+			"items": []interface{}{
+				map[string]interface{}{"title": "Tea", "price": 500},
+				map[string]interface{}{"title": "Cake", "price": 700},
+			},
+			"address": map[string]string{"city": "Tokyo"},
+			"footer":  "<hr>",
+			"coupon":  "unused",
+		},
+	})
+
+	assert.NoError(t, resp.Error)
+	assert.Equal(t, "<Taro>様 ご注文ありがとうございます", resp.Subject)
+	assert.Equal(t, "<h1>&lt;Taro&gt;</h1><p>member</p><ul><li>0:Tea ¥500</li><li>1:Cake ¥700</li></ul><hr>", resp.HTML)
+	assert.Equal(t, "upgrade Tokyo", resp.Text)
+	assert.Empty(t, resp.MissingVariables)
+	assert.Equal(t, []string{"coupon"}, resp.UnusedVariables)
+}
+
+func TestRenderTemplateMissingVariables(t *testing.T) {
+	tmpl := &GetTemplateResponse{
+		SubjectPart: aws.String("Hello {{name}}"),
+		TextPart:    aws.String("{{#if vip}}VIP{{/if}}{{#each items}}{{title}}{{/each}}"),
+	}
+
+	opts := &RenderTemplateOptions{
+		Template: tmpl,
+		Data: map[string]interface{}{
+			"items": []map[string]string{{"name": "Tea"}},
+		},
+	}
+
+	resp := RenderTemplate(opts)
+	assert.NoError(t, resp.Error)
+	// conditions may be absent, only rendered values are reported
+	assert.Equal(t, []string{"items.title", "name"}, resp.MissingVariables)
+
+	opts.Strict = true
+	assert.Error(t, RenderTemplate(opts).Error)
+}
+
+func TestRenderTemplateParseErrors(t *testing.T) {
+	for _, source := range []string{
+		"{{name",
+		"{{#if vip}}unclosed",
+		"{{/if}}",
+		"{{#lookup a b}}{{/lookup}}",
+		"{{#if a}}{{else}}{{else}}{{/if}}",
+	} {
+		resp := RenderTemplate(&RenderTemplateOptions{
+			Template: &GetTemplateResponse{SubjectPart: aws.String(source)},
+		})
+		assert.Error(t, resp.Error, source)
+	}
+}
+
+func TestTemplateVariables(t *testing.T) {
+	vars, err := TemplateVariables("{{user.name}} {{#each items}}{{title}} {{../currency}}{{/each}} {{#if vip}}{{this.rank}}{{/if}}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"currency", "items", "rank", "user", "vip"}, vars)
+
+	// only an exact this or this. prefix refers to the current scope
+	vars, err = TemplateVariables("{{thisMonth}} {{this.total}} {{#each items}}{{this}}{{/each}}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"items", "thisMonth", "total"}, vars)
+
+	resp := RenderTemplate(&RenderTemplateOptions{
+		Template: &GetTemplateResponse{SubjectPart: aws.String("{{thisMonth}}")},
+		Data:     map[string]interface{}{"thisMonth": "October"},
+	})
+	assert.NoError(t, resp.Error)
+	assert.Equal(t, "October", resp.Subject)
+	assert.Empty(t, resp.UnusedVariables)
+}
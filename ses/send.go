@@ -0,0 +1,182 @@
+package ses
+
+import (
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	sesv2 "github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// DefaultCharset charset used for simple email content
+const DefaultCharset = "UTF-8"
+
+// SendSimpleEmailOptions send email with ad-hoc subject and body options
+type SendSimpleEmailOptions struct {
+	Sender     string
+	Recipients []string
+	CCs        []string
+	BCCs       []string
+	ReplyTo    []string
+	// ReturnPath address bounces and complaints are forwarded to
+	ReturnPath string
+	Subject    string
+	HTML       string
+	Text       string
+	// Charset defaults to UTF-8
+	Charset          string
+	Headers          map[string]string
+	Tag              map[string]string
+	ConfigurationSet *string
+	Timeout          time.Duration
+}
+
+// SendRawEmailOptions send raw MIME email options
+type SendRawEmailOptions struct {
+	// Sender optional, defaults to the From header of the message
+	Sender string
+	// Recipients, CCs and BCCs optional, default to the To, Cc and Bcc headers of the message
+	Recipients       []string
+	CCs              []string
+	BCCs             []string
+	Data             []byte
+	Tag              map[string]string
+	ConfigurationSet *string
+	Timeout          time.Duration
+}
+
+// SendContentEmailResponse send simple or raw email response
+type SendContentEmailResponse struct {
+	MessageID string
+	Error     error
+}
+
+// SendSimpleEmail sends an email with the given subject, HTML and text bodies without a template
+func (s *Service) SendSimpleEmail(opts *SendSimpleEmailOptions) (resp *SendContentEmailResponse) {
+	if s.local != nil {
+		resp = new(SendContentEmailResponse)
+		resp.MessageID, resp.Error = s.local.sendSimple(opts)
+		return
+	}
+
+	charset := DefaultCharset
+	if opts.Charset != "" {
+		charset = opts.Charset
+	}
+
+	body := new(sesv2types.Body)
+	if opts.HTML != "" {
+		body.Html = &sesv2types.Content{Data: aws.String(opts.HTML), Charset: aws.String(charset)}
+	}
+
+	if opts.Text != "" {
+		body.Text = &sesv2types.Content{Data: aws.String(opts.Text), Charset: aws.String(charset)}
+	}
+
+	message := &sesv2types.Message{
+		Subject: &sesv2types.Content{Data: aws.String(opts.Subject), Charset: aws.String(charset)},
+		Body:    body,
+	}
+
+	for k, v := range opts.Headers {
+		message.Headers = append(message.Headers, sesv2types.MessageHeader{
+			Name:  aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	input := &sesv2.SendEmailInput{
+		FromEmailAddress:     aws.String(opts.Sender),
+		Destination:          toDestinationV2(opts.Recipients, opts.CCs, opts.BCCs),
+		ReplyToAddresses:     opts.ReplyTo,
+		Content:              &sesv2types.EmailContent{Simple: message},
+		EmailTags:            toMessageTagsV2(opts.Tag),
+		ConfigurationSetName: opts.ConfigurationSet,
+	}
+
+	if opts.ReturnPath != "" {
+		input.FeedbackForwardingEmailAddress = aws.String(opts.ReturnPath)
+	}
+
+	return s.sendEmailV2(input, opts.Timeout)
+}
+
+// SendRawEmail sends a raw MIME message, see MessageBuilder to compose one
+func (s *Service) SendRawEmail(opts *SendRawEmailOptions) (resp *SendContentEmailResponse) {
+	if s.local != nil {
+		resp = new(SendContentEmailResponse)
+		resp.MessageID, resp.Error = s.local.sendRaw(opts)
+		return
+	}
+
+	input := &sesv2.SendEmailInput{
+		Content:              &sesv2types.EmailContent{Raw: &sesv2types.RawMessage{Data: opts.Data}},
+		EmailTags:            toMessageTagsV2(opts.Tag),
+		ConfigurationSetName: opts.ConfigurationSet,
+	}
+
+	if opts.Sender != "" {
+		input.FromEmailAddress = aws.String(opts.Sender)
+	}
+
+	if len(opts.Recipients)+len(opts.CCs)+len(opts.BCCs) > 0 {
+		input.Destination = toDestinationV2(opts.Recipients, opts.CCs, opts.BCCs)
+	}
+
+	return s.sendEmailV2(input, opts.Timeout)
+}
+
+// sendEmailV2 sends the email through the sesv2 client
+func (s *Service) sendEmailV2(input *sesv2.SendEmailInput, timeout time.Duration) (resp *SendContentEmailResponse) {
+	resp = new(SendContentEmailResponse)
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if timeout > 0 {
+		t = timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.SendEmail(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.MessageID = aws.StringValue(output.MessageId)
+	return
+}
+
+// toDestinationV2 builds a sesv2 destination
+func toDestinationV2(recipients, ccs, bccs []string) *sesv2types.Destination {
+	dest := &sesv2types.Destination{
+		ToAddresses: recipients,
+	}
+
+	if len(ccs) > 0 {
+		dest.CcAddresses = ccs
+	}
+
+	if len(bccs) > 0 {
+		dest.BccAddresses = bccs
+	}
+
+	return dest
+}
+
+// toMessageTagsV2 converts a tag map to sesv2 message tags
+func toMessageTagsV2(tags map[string]string) []sesv2types.MessageTag {
+	var result []sesv2types.MessageTag
+	for k, v := range tags {
+		result = append(result, sesv2types.MessageTag{
+			Name:  aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	return result
+}
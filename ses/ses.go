@@ -84,6 +84,7 @@ type ListTemplatesOptions struct {
 type ListTemplatesResponse struct {
 	Error     error
 	Templates []string
+	NextToken *string
 }
 
 // GetTemplateOptions get templates options
@@ -123,9 +124,11 @@ type GetSuppressedDestinationResponse struct {
 	Error               error
 }
 
+// SuppressedEmail address in the account suppression list
 type SuppressedEmail struct {
-	Email  string
-	Reason string
+	Email          string
+	Reason         string
+	LastUpdateTime time.Time
 }
 
 // Context context includes endpoint, region and other info
@@ -138,6 +141,8 @@ type Service struct {
 	context      *context
 	accessKey    string
 	accessSecret string
+	// local set by NewLocalService, sends without SES
+	local *localBackend
 }
 
 // NewService service initializer
@@ -204,6 +209,10 @@ func (s *Service) clientv2() *sesv2.Client {
 // SendEmail send email
 func (s *Service) SendEmail(opts *SendEmailOptions) (resp *SendEmailResponse) {
 	resp = new(SendEmailResponse)
+	if s.local != nil {
+		_, resp.Error = s.local.sendTemplated(opts.Sender, opts.Recipients, opts.CCs, opts.BCCs, opts.Template, opts.TemplateData, nil)
+		return
+	}
 
 	client := s.client()
 	t := 30 * time.Second
@@ -294,6 +303,11 @@ func (c *context) check() {
 // CreateTemplate creates an SES email template
 func (s *Service) CreateTemplate(opts *CreateTemplateOptions) (resp *CreateTemplateResponse) {
 	resp = new(CreateTemplateResponse)
+	if s.local != nil {
+		resp.Error = s.local.putTemplate(opts, true)
+		return
+	}
+
 	client := s.client()
 	input := &ses.CreateTemplateInput{
 		Template: &ses.Template{
@@ -315,6 +329,11 @@ func (s *Service) CreateTemplate(opts *CreateTemplateOptions) (resp *CreateTempl
 // UpdateTemplate updates an existing SES email template
 func (s *Service) UpdateTemplate(opts *UpdateTemplateOptions) (resp *UpdateTemplateResponse) {
 	resp = new(UpdateTemplateResponse)
+	if s.local != nil {
+		resp.Error = s.local.putTemplate((*CreateTemplateOptions)(opts), false)
+		return
+	}
+
 	client := s.client()
 	input := &ses.UpdateTemplateInput{
 		Template: &ses.Template{
@@ -336,6 +355,11 @@ func (s *Service) UpdateTemplate(opts *UpdateTemplateOptions) (resp *UpdateTempl
 // DeleteTemplate deletes an SES email template by name
 func (s *Service) DeleteTemplate(opts *DeleteTemplateOptions) (resp *DeleteTemplateResponse) {
 	resp = new(DeleteTemplateResponse)
+	if s.local != nil {
+		resp.Error = s.local.deleteTemplate(opts.TemplateName)
+		return
+	}
+
 	client := s.client()
 	input := &ses.DeleteTemplateInput{
 		TemplateName: aws.String(opts.TemplateName),
@@ -352,6 +376,11 @@ func (s *Service) DeleteTemplate(opts *DeleteTemplateOptions) (resp *DeleteTempl
 // ListTemplates lists SES email templates
 func (s *Service) ListTemplates(opts *ListTemplatesOptions) (resp *ListTemplatesResponse) {
 	resp = new(ListTemplatesResponse)
+	if s.local != nil {
+		resp.Templates = s.local.listTemplates()
+		return
+	}
+
 	client := s.client()
 	input := &ses.ListTemplatesInput{
 		MaxItems:  opts.MaxItems,
@@ -368,11 +397,36 @@ func (s *Service) ListTemplates(opts *ListTemplatesOptions) (resp *ListTemplates
 		resp.Templates = append(resp.Templates, *template.Name)
 	}
 
+	resp.NextToken = result.NextToken
 	return
 }
 
+// ListAllTemplates lists SES email templates, following NextToken through every page
+func (s *Service) ListAllTemplates() (resp *ListTemplatesResponse) {
+	resp = new(ListTemplatesResponse)
+	opts := new(ListTemplatesOptions)
+	for {
+		page := s.ListTemplates(opts)
+		if page.Error != nil {
+			resp.Error = page.Error
+			return
+		}
+
+		resp.Templates = append(resp.Templates, page.Templates...)
+		if aws.StringValue(page.NextToken) == "" {
+			return
+		}
+
+		opts.NextToken = page.NextToken
+	}
+}
+
 // GetTemplate retrieves details of an SES email template by name
 func (s *Service) GetTemplate(opts *GetTemplateOptions) (resp *GetTemplateResponse) {
+	if s.local != nil {
+		return s.local.getTemplate(opts.TemplateName)
+	}
+
 	resp = new(GetTemplateResponse)
 	client := s.client()
 	input := &ses.GetTemplateInput{
@@ -393,22 +447,21 @@ func (s *Service) GetTemplate(opts *GetTemplateOptions) (resp *GetTemplateRespon
 	return
 }
 
-// GetTemplateVariables retrieves template variables of an SES email template by name
+// GetTemplateVariables retrieves template variables of an SES email template by name,
+// see RenderTemplate to validate Handlebars helpers and data
 func (s *Service) GetTemplateVariables(opts *GetTemplateVariableOptions) (resp *GetTemplateVariableResponse) {
 	resp = new(GetTemplateVariableResponse)
-	client := s.client()
-	input := &ses.GetTemplateInput{
-		TemplateName: aws.String(opts.TemplateName),
-	}
+	result := s.GetTemplate(&GetTemplateOptions{
+		TemplateName: opts.TemplateName,
+	})
 
-	result, err := client.GetTemplate(input)
-	if err != nil {
-		resp.Error = err
+	if result.Error != nil {
+		resp.Error = result.Error
 		return
 	}
 
 	// Combine subject, text, and HTML parts into one string
-	templateContent := fmt.Sprintf("%s %s", *result.Template.SubjectPart, *result.Template.HtmlPart)
+	templateContent := fmt.Sprintf("%s %s %s", aws.StringValue(result.SubjectPart), aws.StringValue(result.TextPart), aws.StringValue(result.HtmlPart))
 	// Use a regular expression to find all placeholders in the template
 	resp.Variables = regexp.MustCompile(`{{(.*?)}}`).FindAllString(templateContent, -1)
 	return
@@ -420,6 +473,12 @@ func (s *Service) GetSuppressedDestination(opts *GetSuppressedDestinationOptions
 		SuppressedEmailList: []*SuppressedEmail{},
 	}
 
+	// the local suppression list is returned as a single page
+	if s.local != nil {
+		resp.SuppressedEmailList = s.local.listSuppressed(new(ListAllSuppressedDestinationsOptions))
+		return
+	}
+
 	client := s.clientv2()
 	input := new(sesv2.ListSuppressedDestinationsInput)
 	if opts.NextToken != "" {
@@ -437,8 +496,9 @@ func (s *Service) GetSuppressedDestination(opts *GetSuppressedDestinationOptions
 
 	for _, destination := range result.SuppressedDestinationSummaries {
 		resp.SuppressedEmailList = append(resp.SuppressedEmailList, &SuppressedEmail{
-			Email:  *destination.EmailAddress,
-			Reason: string(destination.Reason),
+			Email:          *destination.EmailAddress,
+			Reason:         string(destination.Reason),
+			LastUpdateTime: aws.TimeValue(destination.LastUpdateTime),
 		})
 	}
 
@@ -123,3 +123,21 @@ func TestGetSuppressedDestination(t *testing.T) {
 	assert.NoError(t, resp.Error)
 	assert.True(t, len(resp.SuppressedEmailList) > 0)
 }
+
+func TestSendSimpleEmail(t *testing.T) {
+	svc := NewService(os.Getenv("WS_SES_AWS_ACCESS_KEY_ID"), os.Getenv("WS_SES_AWS_SECRET_ACCESS_KEY"))
+	svc.SetRegion("ap-northeast-1")
+
+	resp := svc.SendSimpleEmail(&SendSimpleEmailOptions{
+		Sender:           "contact@woodstock.club",
+		Recipients:       []string{"min@woodstock.club"},
+		ReplyTo:          []string{"support@woodstock.club"},
+		Subject:          "test",
+		HTML:             "<html><body><h1>Hello</h1></body></html>",
+		Text:             "Hello",
+		ConfigurationSet: aws.String("managed-dedicated-ip"),
+	})
+
+	assert.NoError(t, resp.Error)
+	assert.NotEmpty(t, resp.MessageID)
+}
@@ -0,0 +1,288 @@
+package ses
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	sesv2 "github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// SuppressionReason reason an address is in the suppression list
+type SuppressionReason string
+
+const (
+	SuppressionReasonBounce    SuppressionReason = "BOUNCE"
+	SuppressionReasonComplaint SuppressionReason = "COMPLAINT"
+)
+
+// LookupSuppressedDestinationOptions look up a single suppressed address options
+type LookupSuppressedDestinationOptions struct {
+	Email   string
+	Timeout time.Duration
+}
+
+// LookupSuppressedDestinationResponse look up a single suppressed address response
+type LookupSuppressedDestinationResponse struct {
+	Suppressed bool
+	// Destination set when Suppressed
+	Destination *SuppressedEmail
+	Error       error
+}
+
+// PutSuppressedDestinationOptions add an address to the suppression list options
+type PutSuppressedDestinationOptions struct {
+	Email   string
+	Reason  SuppressionReason
+	Timeout time.Duration
+}
+
+// PutSuppressedDestinationResponse add an address to the suppression list response
+type PutSuppressedDestinationResponse struct {
+	Error error
+}
+
+// DeleteSuppressedDestinationOptions remove an address from the suppression list options
+type DeleteSuppressedDestinationOptions struct {
+	Email   string
+	Timeout time.Duration
+}
+
+// DeleteSuppressedDestinationResponse remove an address from the suppression list response
+type DeleteSuppressedDestinationResponse struct {
+	Error error
+}
+
+// ListAllSuppressedDestinationsOptions list every suppressed address options, zero filters match all
+type ListAllSuppressedDestinationsOptions struct {
+	Reasons   []SuppressionReason
+	StartDate time.Time
+	EndDate   time.Time
+	// Timeout per page
+	Timeout time.Duration
+}
+
+// FilterSuppressedRecipientsResponse filter suppressed recipients response
+type FilterSuppressedRecipientsResponse struct {
+	Removed []string
+	Error   error
+}
+
+// LookupSuppressedDestination checks whether a single address is in the account suppression list
+func (s *Service) LookupSuppressedDestination(opts *LookupSuppressedDestinationOptions) (resp *LookupSuppressedDestinationResponse) {
+	resp = new(LookupSuppressedDestinationResponse)
+	if s.local != nil {
+		resp.Destination = s.local.lookupSuppressed(opts.Email)
+		resp.Suppressed = resp.Destination != nil
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.GetSuppressedDestination(ctx, &sesv2.GetSuppressedDestinationInput{
+		EmailAddress: aws.String(opts.Email),
+	})
+
+	var notFound *sesv2types.NotFoundException
+	if errors.As(err, &notFound) {
+		return
+	}
+
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.Suppressed = true
+	resp.Destination = &SuppressedEmail{
+		Email:          aws.StringValue(output.SuppressedDestination.EmailAddress),
+		Reason:         string(output.SuppressedDestination.Reason),
+		LastUpdateTime: aws.TimeValue(output.SuppressedDestination.LastUpdateTime),
+	}
+
+	return
+}
+
+// PutSuppressedDestination adds an address to the account suppression list
+func (s *Service) PutSuppressedDestination(opts *PutSuppressedDestinationOptions) (resp *PutSuppressedDestinationResponse) {
+	resp = new(PutSuppressedDestinationResponse)
+	if s.local != nil {
+		s.local.putSuppressed(opts.Email, opts.Reason)
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.PutSuppressedDestination(ctx, &sesv2.PutSuppressedDestinationInput{
+		EmailAddress: aws.String(opts.Email),
+		Reason:       sesv2types.SuppressionListReason(opts.Reason),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// DeleteSuppressedDestination removes an address from the account suppression list
+func (s *Service) DeleteSuppressedDestination(opts *DeleteSuppressedDestinationOptions) (resp *DeleteSuppressedDestinationResponse) {
+	resp = new(DeleteSuppressedDestinationResponse)
+	if s.local != nil {
+		resp.Error = s.local.deleteSuppressed(opts.Email)
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.DeleteSuppressedDestination(ctx, &sesv2.DeleteSuppressedDestinationInput{
+		EmailAddress: aws.String(opts.Email),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// ListAllSuppressedDestinations lists every suppressed address matching the filters, following NextToken through every page
+func (s *Service) ListAllSuppressedDestinations(opts *ListAllSuppressedDestinationsOptions) (resp *GetSuppressedDestinationResponse) {
+	resp = &GetSuppressedDestinationResponse{
+		SuppressedEmailList: []*SuppressedEmail{},
+	}
+
+	if s.local != nil {
+		resp.SuppressedEmailList = s.local.listSuppressed(opts)
+		return
+	}
+
+	client := s.clientv2()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+
+	input := new(sesv2.ListSuppressedDestinationsInput)
+	for _, reason := range opts.Reasons {
+		input.Reasons = append(input.Reasons, sesv2types.SuppressionListReason(reason))
+	}
+
+	if !opts.StartDate.IsZero() {
+		input.StartDate = aws.Time(opts.StartDate)
+	}
+
+	if !opts.EndDate.IsZero() {
+		input.EndDate = aws.Time(opts.EndDate)
+	}
+
+	paginator := sesv2.NewListSuppressedDestinationsPaginator(client, input)
+	for paginator.HasMorePages() {
+		ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+		page, err := paginator.NextPage(ctx)
+		cancel()
+
+		if err != nil {
+			resp.Error = err
+			return
+		}
+
+		for _, destination := range page.SuppressedDestinationSummaries {
+			resp.SuppressedEmailList = append(resp.SuppressedEmailList, &SuppressedEmail{
+				Email:          aws.StringValue(destination.EmailAddress),
+				Reason:         string(destination.Reason),
+				LastUpdateTime: aws.TimeValue(destination.LastUpdateTime),
+			})
+		}
+	}
+
+	return
+}
+
+// FilterSuppressedRecipients removes suppressed addresses from the Recipients, CCs and BCCs of opts in place
+func (s *Service) FilterSuppressedRecipients(opts *SendEmailOptions) (resp *FilterSuppressedRecipientsResponse) {
+	resp = new(FilterSuppressedRecipientsResponse)
+
+	isSuppressed := func(email string) (bool, error) {
+		lookup := s.LookupSuppressedDestination(&LookupSuppressedDestinationOptions{
+			Email:   email,
+			Timeout: opts.Timeout,
+		})
+		return lookup.Suppressed, lookup.Error
+	}
+
+	resp.Removed, resp.Error = filterSuppressed(opts, isSuppressed)
+	return
+}
+
+// filterSuppressed filters the addresses of opts, looking up each distinct address once.
+// Display names are stripped for the lookup, removed and kept addresses are returned as given
+func filterSuppressed(opts *SendEmailOptions, isSuppressed func(email string) (bool, error)) ([]string, error) {
+	checked := map[string]bool{}
+	var removed []string
+
+	filter := func(addresses []string) ([]string, error) {
+		var kept []string
+		for _, address := range addresses {
+			key := strings.ToLower(strings.TrimSpace(addressOnly(address)))
+			suppressed, ok := checked[key]
+			if !ok {
+				var err error
+				if suppressed, err = isSuppressed(key); err != nil {
+					return nil, err
+				}
+				checked[key] = suppressed
+			}
+
+			if suppressed {
+				removed = append(removed, address)
+				continue
+			}
+
+			kept = append(kept, address)
+		}
+
+		return kept, nil
+	}
+
+	recipients, err := filter(opts.Recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	ccs, err := filter(opts.CCs)
+	if err != nil {
+		return nil, err
+	}
+
+	bccs, err := filter(opts.BCCs)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Recipients, opts.CCs, opts.BCCs = recipients, ccs, bccs
+	return removed, nil
+}
@@ -0,0 +1,41 @@
+package ses
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSuppressed(t *testing.T) {
+	suppressed := map[string]bool{"bounced@woodstock.club": true}
+	lookups := 0
+	isSuppressed := func(email string) (bool, error) {
+		lookups++
+		return suppressed[email], nil
+	}
+
+	opts := &SendEmailOptions{
+		Recipients: []string{"min@woodstock.club", "Bounced@woodstock.club"},
+		CCs:        []string{"Bounced <bounced@woodstock.club>"},
+		BCCs:       []string{"Min <min@woodstock.club>"},
+	}
+
+	removed, err := filterSuppressed(opts, isSuppressed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bounced@woodstock.club", "Bounced <bounced@woodstock.club>"}, removed)
+	assert.Equal(t, []string{"min@woodstock.club"}, opts.Recipients)
+	assert.Empty(t, opts.CCs)
+	assert.Equal(t, []string{"Min <min@woodstock.club>"}, opts.BCCs)
+	assert.Equal(t, 2, lookups)
+}
+
+func TestFilterSuppressedError(t *testing.T) {
+	opts := &SendEmailOptions{Recipients: []string{"min@woodstock.club"}}
+	_, err := filterSuppressed(opts, func(email string) (bool, error) {
+		return false, errors.New("throttled")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"min@woodstock.club"}, opts.Recipients)
+}
@@ -0,0 +1,239 @@
+package ses
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// template file names inside a template directory
+const (
+	templateSubjectFile = "subject.txt"
+	templateHTMLFile    = "body.html"
+	templateTextFile    = "body.txt"
+)
+
+// TemplateSyncAction action taken for a template during sync
+type TemplateSyncAction string
+
+const (
+	TemplateSyncActionCreate    TemplateSyncAction = "create"
+	TemplateSyncActionUpdate    TemplateSyncAction = "update"
+	TemplateSyncActionDelete    TemplateSyncAction = "delete"
+	TemplateSyncActionUnchanged TemplateSyncAction = "unchanged"
+)
+
+// SyncTemplatesOptions sync templates options
+//
+// Dir holds one directory per template, named after the template:
+//
+//	<Dir>/<name>/subject.txt
+//	<Dir>/<name>/body.html (optional)
+//	<Dir>/<name>/body.txt  (optional)
+type SyncTemplatesOptions struct {
+	Dir string
+	// Prefix only templates whose names start with Prefix are managed
+	Prefix string
+	// Delete deletes managed remote templates missing from Dir
+	Delete bool
+	// DryRun plans changes without applying them
+	DryRun bool
+}
+
+// TemplateSyncChange planned or applied change of a single template
+type TemplateSyncChange struct {
+	TemplateName string
+	Action       TemplateSyncAction
+	Error        error
+}
+
+// SyncTemplatesResponse sync templates response, Plan is sorted by template name
+type SyncTemplatesResponse struct {
+	Plan  []*TemplateSyncChange
+	Error error
+}
+
+// LoadTemplates loads templates from a directory, see SyncTemplatesOptions for the layout
+func LoadTemplates(dir string) ([]*CreateTemplateOptions, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []*CreateTemplateOptions
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		subject, err := os.ReadFile(filepath.Join(dir, name, templateSubjectFile))
+		if err != nil {
+			return nil, fmt.Errorf("ses: template %s: %w", name, err)
+		}
+
+		tmpl := &CreateTemplateOptions{
+			TemplateName: name,
+			Subject:      strings.TrimSpace(string(subject)),
+		}
+
+		if tmpl.HTML, err = readOptionalFile(filepath.Join(dir, name, templateHTMLFile)); err != nil {
+			return nil, fmt.Errorf("ses: template %s: %w", name, err)
+		}
+
+		if tmpl.Text, err = readOptionalFile(filepath.Join(dir, name, templateTextFile)); err != nil {
+			return nil, fmt.Errorf("ses: template %s: %w", name, err)
+		}
+
+		if tmpl.HTML == nil && tmpl.Text == nil {
+			return nil, fmt.Errorf("ses: template %s has neither %s nor %s", name, templateHTMLFile, templateTextFile)
+		}
+
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// SyncTemplates creates, updates and optionally deletes SES templates to match a local directory
+func (s *Service) SyncTemplates(opts *SyncTemplatesOptions) (resp *SyncTemplatesResponse) {
+	resp = new(SyncTemplatesResponse)
+
+	local, err := LoadTemplates(opts.Dir)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	list := s.ListAllTemplates()
+	if list.Error != nil {
+		resp.Error = list.Error
+		return
+	}
+
+	remote := map[string]struct{}{}
+	for _, name := range list.Templates {
+		if strings.HasPrefix(name, opts.Prefix) {
+			remote[name] = struct{}{}
+		}
+	}
+
+	var apply []func() error
+	for _, tmpl := range local {
+		if !strings.HasPrefix(tmpl.TemplateName, opts.Prefix) {
+			continue
+		}
+
+		tmpl := tmpl
+		change := &TemplateSyncChange{TemplateName: tmpl.TemplateName, Action: TemplateSyncActionCreate}
+		if _, ok := remote[tmpl.TemplateName]; ok {
+			delete(remote, tmpl.TemplateName)
+
+			current := s.GetTemplate(&GetTemplateOptions{TemplateName: tmpl.TemplateName})
+			if current.Error != nil {
+				resp.Error = current.Error
+				return
+			}
+
+			change.Action = TemplateSyncActionUnchanged
+			if !templateEqual(tmpl, current) {
+				change.Action = TemplateSyncActionUpdate
+			}
+		}
+
+		resp.Plan = append(resp.Plan, change)
+		switch change.Action {
+		case TemplateSyncActionCreate:
+			apply = append(apply, func() error {
+				change.Error = s.CreateTemplate(tmpl).Error
+				return change.Error
+			})
+		case TemplateSyncActionUpdate:
+			apply = append(apply, func() error {
+				change.Error = s.UpdateTemplate((*UpdateTemplateOptions)(tmpl)).Error
+				return change.Error
+			})
+		}
+	}
+
+	if opts.Delete {
+		for name := range remote {
+			change := &TemplateSyncChange{TemplateName: name, Action: TemplateSyncActionDelete}
+			resp.Plan = append(resp.Plan, change)
+			apply = append(apply, func() error {
+				change.Error = s.DeleteTemplate(&DeleteTemplateOptions{TemplateName: change.TemplateName}).Error
+				return change.Error
+			})
+		}
+	}
+
+	sort.Slice(resp.Plan, func(i, j int) bool {
+		return resp.Plan[i].TemplateName < resp.Plan[j].TemplateName
+	})
+
+	if opts.DryRun {
+		return
+	}
+
+	var errs []error
+	for _, fn := range apply {
+		if err := fn(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	resp.Error = errors.Join(errs...)
+
+	return
+}
+
+// String formats the plan, one line per created, updated or deleted template
+func (r *SyncTemplatesResponse) String() string {
+	var b strings.Builder
+	for _, change := range r.Plan {
+		var mark string
+		switch change.Action {
+		case TemplateSyncActionCreate:
+			mark = "+"
+		case TemplateSyncActionUpdate:
+			mark = "~"
+		case TemplateSyncActionDelete:
+			mark = "-"
+		default:
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %s %s", mark, change.Action, change.TemplateName)
+		if change.Error != nil {
+			fmt.Fprintf(&b, " (failed: %s)", change.Error)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// templateEqual compares a local template with the remote one, treating missing and empty parts alike
+func templateEqual(local *CreateTemplateOptions, remote *GetTemplateResponse) bool {
+	return local.Subject == aws.StringValue(remote.SubjectPart) &&
+		aws.StringValue(local.HTML) == aws.StringValue(remote.HtmlPart) &&
+		aws.StringValue(local.Text) == aws.StringValue(remote.TextPart)
+}
+
+// readOptionalFile reads path, returning nil when it does not exist
+func readOptionalFile(path string) (*string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return aws.String(string(data)), nil
+}
@@ -0,0 +1,70 @@
+package ses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTemplateFiles(t *testing.T, dir, name string, files map[string]string) {
+	if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for file, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFiles(t, dir, "welcome", map[string]string{
+		"subject.txt": "Welcome {{name}}\n",
+		"body.html":   "<h1>Hello {{name}}</h1>",
+		"body.txt":    "Hello {{name}}",
+	})
+	writeTemplateFiles(t, dir, "invoice", map[string]string{
+		"subject.txt": "Invoice",
+		"body.txt":    "See attachment",
+	})
+
+	templates, err := LoadTemplates(dir)
+	assert.NoError(t, err)
+	assert.Len(t, templates, 2)
+
+	assert.Equal(t, "invoice", templates[0].TemplateName)
+	assert.Nil(t, templates[0].HTML)
+	assert.Equal(t, "See attachment", aws.StringValue(templates[0].Text))
+
+	assert.Equal(t, "welcome", templates[1].TemplateName)
+	assert.Equal(t, "Welcome {{name}}", templates[1].Subject)
+	assert.True(t, templateEqual(templates[1], &GetTemplateResponse{
+		SubjectPart: aws.String("Welcome {{name}}"),
+		HtmlPart:    aws.String("<h1>Hello {{name}}</h1>"),
+		TextPart:    aws.String("Hello {{name}}"),
+	}))
+	assert.False(t, templateEqual(templates[1], &GetTemplateResponse{
+		SubjectPart: aws.String("Welcome {{name}}"),
+		HtmlPart:    aws.String("<h1>Hi {{name}}</h1>"),
+	}))
+
+	writeTemplateFiles(t, dir, "broken", map[string]string{"subject.txt": "no body"})
+	_, err = LoadTemplates(dir)
+	assert.Error(t, err)
+}
+
+func TestSyncTemplatesPlanString(t *testing.T) {
+	resp := &SyncTemplatesResponse{Plan: []*TemplateSyncChange{
+		{TemplateName: "invoice", Action: TemplateSyncActionUpdate},
+		{TemplateName: "legacy", Action: TemplateSyncActionDelete},
+		{TemplateName: "receipt", Action: TemplateSyncActionUnchanged},
+		{TemplateName: "welcome", Action: TemplateSyncActionCreate},
+	}}
+
+	assert.Equal(t, "~ update invoice\n- delete legacy\n+ create welcome\n", resp.String())
+}
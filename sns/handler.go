@@ -0,0 +1,163 @@
+package sns
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	goctx "context"
+)
+
+// maxMessageSize SNS messages are at most 256KB, the envelope adds some overhead
+const maxMessageSize = 512 * 1024
+
+// NotificationFunc handles a verified Notification message
+type NotificationFunc func(ctx goctx.Context, m *Message) error
+
+// HandlerOptions options for the HTTP/S subscription handler
+type HandlerOptions struct {
+	// CertificateSource source of signing certificates, defaults to fetching from SNS
+	CertificateSource CertificateSource
+	// HTTPClient client used to visit SubscribeURL, defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// AutoConfirm confirms SubscriptionConfirmation messages by visiting their SubscribeURL
+	AutoConfirm bool
+	// AllowedTopicArns restricts accepted messages to these topics, empty accepts any topic
+	AllowedTopicArns []string
+	// OnNotification called with each verified Notification, a non-nil error responds 500 so SNS retries
+	OnNotification NotificationFunc
+	// OnError called with messages rejected by the handler, for logging
+	OnError func(r *http.Request, err error)
+}
+
+// Handler http.Handler receiving SNS HTTP/S deliveries
+type Handler struct {
+	opts     *HandlerOptions
+	verifier *Verifier
+	client   *http.Client
+	allowed  map[string]struct{}
+}
+
+// NewHandler handler initializer, nil options use the defaults
+func NewHandler(opts *HandlerOptions) *Handler {
+	if opts == nil {
+		opts = new(HandlerOptions)
+	}
+
+	h := &Handler{
+		opts:     opts,
+		verifier: NewVerifier(opts.CertificateSource),
+		client:   opts.HTTPClient,
+	}
+
+	if h.client == nil {
+		h.client = http.DefaultClient
+	}
+
+	if len(opts.AllowedTopicArns) > 0 {
+		h.allowed = make(map[string]struct{}, len(opts.AllowedTopicArns))
+		for _, arn := range opts.AllowedTopicArns {
+			h.allowed[arn] = struct{}{}
+		}
+	}
+
+	return h
+}
+
+// NewNotificationFunc adapts a callback taking a typed payload, decoded from the JSON message body
+func NewNotificationFunc[T any](fn func(ctx goctx.Context, m *Message, payload T) error) NotificationFunc {
+	return func(ctx goctx.Context, m *Message) error {
+		var payload T
+		if err := m.Decode(&payload); err != nil {
+			return fmt.Errorf("sns: failed to decode message payload: %w", err)
+		}
+
+		return fn(ctx, m, payload)
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	m, err := ParseMessage(body)
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if h.allowed != nil {
+		if _, ok := h.allowed[m.TopicArn]; !ok {
+			h.reject(w, r, http.StatusForbidden, fmt.Errorf("sns: topic %s is not allowed", m.TopicArn))
+			return
+		}
+	}
+
+	if err = h.verifier.Verify(r.Context(), m); err != nil {
+		h.reject(w, r, http.StatusForbidden, err)
+		return
+	}
+
+	switch m.Type {
+	case MessageTypeSubscriptionConfirmation:
+		if h.opts.AutoConfirm {
+			if err = h.confirm(r.Context(), m); err != nil {
+				h.reject(w, r, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	case MessageTypeNotification:
+		if h.opts.OnNotification != nil {
+			if err = h.opts.OnNotification(r.Context(), m); err != nil {
+				h.reject(w, r, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// confirm visits the SubscribeURL of a confirmation message, which must point to SNS
+func (h *Handler) confirm(ctx goctx.Context, m *Message) error {
+	u, err := url.Parse(m.SubscribeURL)
+	if err != nil || u.Scheme != "https" || !signingCertHostPattern.MatchString(u.Hostname()) {
+		return fmt.Errorf("sns: invalid subscribe url %q", m.SubscribeURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.SubscribeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("sns: failed to confirm subscription: %s", res.Status)
+	}
+
+	return nil
+}
+
+// reject responds with status and reports err
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.opts.OnError != nil {
+		h.opts.OnError(r, err)
+	}
+
+	w.WriteHeader(status)
+}
@@ -0,0 +1,135 @@
+package sns
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goctx "context"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc stubs the client used to visit SubscribeURL
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func postMessage(t *testing.T, h http.Handler, m *Message) *httptest.ResponseRecorder {
+	body, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sns", bytes.NewReader(body)))
+	return rec
+}
+
+func TestHandlerNotification(t *testing.T) {
+	type order struct {
+		OrderID string `json:"order_id"`
+		Amount  int    `json:"amount"`
+	}
+
+	signer := newTestSigner(t)
+	var received []order
+	h := NewHandler(&HandlerOptions{
+		CertificateSource: signer,
+		OnNotification: NewNotificationFunc(func(ctx goctx.Context, m *Message, payload order) error {
+			received = append(received, payload)
+			return nil
+		}),
+	})
+
+	m := testNotification()
+	signer.sign(t, m)
+	assert.Equal(t, http.StatusOK, postMessage(t, h, m).Code)
+	assert.Equal(t, []order{{OrderID: "o-1", Amount: 1200}}, received)
+
+	m.Message = `{"order_id":"forged"}`
+	assert.Equal(t, http.StatusForbidden, postMessage(t, h, m).Code)
+	assert.Len(t, received, 1)
+}
+
+func TestHandlerCallbackError(t *testing.T) {
+	signer := newTestSigner(t)
+	var reported error
+	h := NewHandler(&HandlerOptions{
+		CertificateSource: signer,
+		OnNotification: func(ctx goctx.Context, m *Message) error {
+			return errors.New("database unavailable")
+		},
+		OnError: func(r *http.Request, err error) {
+			reported = err
+		},
+	})
+
+	m := testNotification()
+	signer.sign(t, m)
+	assert.Equal(t, http.StatusInternalServerError, postMessage(t, h, m).Code)
+	assert.EqualError(t, reported, "database unavailable")
+}
+
+func TestHandlerAutoConfirm(t *testing.T) {
+	signer := newTestSigner(t)
+	var visited []string
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		visited = append(visited, r.URL.String())
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	})}
+
+	h := NewHandler(&HandlerOptions{
+		CertificateSource: signer,
+		HTTPClient:        client,
+		AutoConfirm:       true,
+		AllowedTopicArns:  []string{"arn:aws:sns:ap-northeast-1:123456789012:orders"},
+	})
+
+	confirmation := &Message{
+		Type:             MessageTypeSubscriptionConfirmation,
+		MessageID:        "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+		Token:            "2336412f37f",
+		TopicArn:         "arn:aws:sns:ap-northeast-1:123456789012:orders",
+		Message:          "You have chosen to subscribe to the topic",
+		SubscribeURL:     "https://sns.ap-northeast-1.amazonaws.com/?Action=ConfirmSubscription&Token=2336412f37f",
+		Timestamp:        "2024-05-01T09:30:15.123Z",
+		SignatureVersion: "2",
+	}
+	signer.sign(t, confirmation)
+	assert.Equal(t, http.StatusOK, postMessage(t, h, confirmation).Code)
+	assert.Equal(t, []string{confirmation.SubscribeURL}, visited)
+
+	// topics outside the allow-list are never confirmed
+	confirmation.TopicArn = "arn:aws:sns:ap-northeast-1:123456789012:other"
+	signer.sign(t, confirmation)
+	assert.Equal(t, http.StatusForbidden, postMessage(t, h, confirmation).Code)
+	assert.Len(t, visited, 1)
+}
+
+func TestHandlerRejectsBadRequests(t *testing.T) {
+	h := NewHandler(&HandlerOptions{CertificateSource: newTestSigner(t)})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sns", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sns", bytes.NewReader([]byte("not json"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestNewHandlerDefaults(t *testing.T) {
+	h := NewHandler(nil)
+	assert.Equal(t, http.DefaultClient, h.client)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sns", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,271 @@
+package sns
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	goctx "context"
+)
+
+// MessageType SNS message type
+type MessageType string
+
+const (
+	MessageTypeNotification             MessageType = "Notification"
+	MessageTypeSubscriptionConfirmation MessageType = "SubscriptionConfirmation"
+	MessageTypeUnsubscribeConfirmation  MessageType = "UnsubscribeConfirmation"
+)
+
+var (
+	// ErrInvalidSignature the message signature does not match the signing certificate
+	ErrInvalidSignature = errors.New("sns: invalid message signature")
+	// ErrUnsupportedSignatureVersion the message signature version is neither 1 nor 2
+	ErrUnsupportedSignatureVersion = errors.New("sns: unsupported signature version")
+	// ErrInvalidCertURL the signing certificate url does not point to SNS
+	ErrInvalidCertURL = errors.New("sns: invalid signing certificate url")
+)
+
+// signingCertHostPattern hosts SNS serves signing certificates from
+var signingCertHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// MessageAttribute SNS message attribute
+type MessageAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// Message SNS JSON envelope, as delivered to HTTP/S endpoints and to SQS without raw message delivery
+type Message struct {
+	Type              MessageType                 `json:"Type"`
+	MessageID         string                      `json:"MessageId"`
+	Token             string                      `json:"Token,omitempty"`
+	TopicArn          string                      `json:"TopicArn"`
+	Subject           string                      `json:"Subject,omitempty"`
+	Message           string                      `json:"Message"`
+	Timestamp         string                      `json:"Timestamp"`
+	SignatureVersion  string                      `json:"SignatureVersion"`
+	Signature         string                      `json:"Signature"`
+	SigningCertURL    string                      `json:"SigningCertURL"`
+	SubscribeURL      string                      `json:"SubscribeURL,omitempty"`
+	UnsubscribeURL    string                      `json:"UnsubscribeURL,omitempty"`
+	MessageAttributes map[string]MessageAttribute `json:"MessageAttributes,omitempty"`
+}
+
+// ParseMessage decodes an SNS JSON envelope
+func ParseMessage(data []byte) (*Message, error) {
+	m := new(Message)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	switch m.Type {
+	case MessageTypeNotification, MessageTypeSubscriptionConfirmation, MessageTypeUnsubscribeConfirmation:
+	default:
+		return nil, fmt.Errorf("sns: unknown message type %q", m.Type)
+	}
+
+	return m, nil
+}
+
+// Decode decodes the JSON message payload into v
+func (m *Message) Decode(v interface{}) error {
+	return json.Unmarshal([]byte(m.Message), v)
+}
+
+// SentAt time the message was published
+func (m *Message) SentAt() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, m.Timestamp)
+}
+
+// stringToSign builds the canonical string the signature is computed over
+func (m *Message) stringToSign() string {
+	var fields [][2]string
+	switch m.Type {
+	case MessageTypeNotification:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+		}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields,
+			[2]string{"Timestamp", m.Timestamp},
+			[2]string{"TopicArn", m.TopicArn},
+			[2]string{"Type", string(m.Type)},
+		)
+	default:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicArn},
+			{"Type", string(m.Type)},
+		}
+	}
+
+	var b strings.Builder
+	for _, field := range fields {
+		b.WriteString(field[0])
+		b.WriteString("\n")
+		b.WriteString(field[1])
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// CertificateSource provides the certificate a message was signed with
+type CertificateSource interface {
+	Certificate(ctx goctx.Context, certURL string) (*x509.Certificate, error)
+}
+
+// HTTPCertificateSource fetches signing certificates from SNS over HTTPS and caches them by url
+type HTTPCertificateSource struct {
+	client *http.Client
+	mu     sync.Mutex
+	certs  map[string]*x509.Certificate
+}
+
+// NewHTTPCertificateSource http certificate source initializer, a nil client uses http.DefaultClient
+func NewHTTPCertificateSource(client *http.Client) *HTTPCertificateSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HTTPCertificateSource{
+		client: client,
+		certs:  map[string]*x509.Certificate{},
+	}
+}
+
+// Certificate fetches the certificate, refusing urls not served by SNS over HTTPS
+func (src *HTTPCertificateSource) Certificate(ctx goctx.Context, certURL string) (*x509.Certificate, error) {
+	u, err := url.Parse(certURL)
+	if err != nil || u.Scheme != "https" || !signingCertHostPattern.MatchString(u.Hostname()) || !strings.HasSuffix(u.Path, ".pem") {
+		return nil, ErrInvalidCertURL
+	}
+
+	src.mu.Lock()
+	cert, ok := src.certs[certURL]
+	src.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := src.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sns: failed to fetch signing certificate: %s", res.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err = ParseCertificate(body)
+	if err != nil {
+		return nil, err
+	}
+
+	src.mu.Lock()
+	src.certs[certURL] = cert
+	src.mu.Unlock()
+	return cert, nil
+}
+
+// ParseCertificate parses a PEM encoded signing certificate
+func ParseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("sns: signing certificate is not PEM encoded")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// Verifier verifies SNS message signatures
+type Verifier struct {
+	source CertificateSource
+}
+
+// NewVerifier verifier initializer, a nil source fetches certificates from SNS over HTTPS
+func NewVerifier(source CertificateSource) *Verifier {
+	if source == nil {
+		source = NewHTTPCertificateSource(nil)
+	}
+
+	return &Verifier{
+		source: source,
+	}
+}
+
+// Verify verifies the message signature, supporting SignatureVersion 1 (SHA1) and 2 (SHA256)
+func (v *Verifier) Verify(ctx goctx.Context, m *Message) error {
+	var hash crypto.Hash
+	switch m.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return ErrUnsupportedSignatureVersion
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	cert, err := v.source.Certificate(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("sns: signing certificate does not hold an RSA key")
+	}
+
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(m.stringToSign()))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(m.stringToSign()))
+		digest = sum[:]
+	}
+
+	if err = rsa.VerifyPKCS1v15(pub, hash, digest, signature); err != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package sns
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	goctx "context"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCertURL = "https://sns.ap-northeast-1.amazonaws.com/SimpleNotificationService-test.pem"
+
+// testSigner signs messages with a locally generated certificate
+type testSigner struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testSigner{key: key, cert: cert}
+}
+
+// Certificate implements CertificateSource
+func (ts *testSigner) Certificate(ctx goctx.Context, certURL string) (*x509.Certificate, error) {
+	if certURL != testCertURL {
+		return nil, ErrInvalidCertURL
+	}
+
+	return ts.cert, nil
+}
+
+func (ts *testSigner) sign(t *testing.T, m *Message) {
+	m.SigningCertURL = testCertURL
+
+	var (
+		hash   crypto.Hash
+		digest []byte
+	)
+	if m.SignatureVersion == "1" {
+		sum := sha1.Sum([]byte(m.stringToSign()))
+		hash, digest = crypto.SHA1, sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(m.stringToSign()))
+		hash, digest = crypto.SHA256, sum[:]
+	}
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, ts.key, hash, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Signature = base64.StdEncoding.EncodeToString(sig)
+}
+
+func testNotification() *Message {
+	return &Message{
+		Type:             MessageTypeNotification,
+		MessageID:        "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:         "arn:aws:sns:ap-northeast-1:123456789012:orders",
+		Subject:          "order",
+		Message:          `{"order_id":"o-1","amount":1200}`,
+		Timestamp:        "2024-05-01T09:30:15.123Z",
+		SignatureVersion: "2",
+		MessageAttributes: map[string]MessageAttribute{
+			"event": {Type: "String", Value: "order_created"},
+		},
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	data, err := json.Marshal(testNotification())
+	assert.NoError(t, err)
+
+	m, err := ParseMessage(data)
+	assert.NoError(t, err)
+	assert.Equal(t, MessageTypeNotification, m.Type)
+	assert.Equal(t, "order_created", m.MessageAttributes["event"].Value)
+
+	var payload struct {
+		OrderID string `json:"order_id"`
+		Amount  int    `json:"amount"`
+	}
+	assert.NoError(t, m.Decode(&payload))
+	assert.Equal(t, "o-1", payload.OrderID)
+	assert.Equal(t, 1200, payload.Amount)
+
+	sentAt, err := m.SentAt()
+	assert.NoError(t, err)
+	assert.Equal(t, 2024, sentAt.Year())
+
+	_, err = ParseMessage([]byte(`{"Type":"Unknown"}`))
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	signer := newTestSigner(t)
+	verifier := NewVerifier(signer)
+
+	for _, version := range []string{"1", "2"} {
+		m := testNotification()
+		m.SignatureVersion = version
+		signer.sign(t, m)
+		assert.NoError(t, verifier.Verify(goctx.Background(), m), "signature version %s", version)
+
+		m.Message = `{"order_id":"o-2"}`
+		assert.ErrorIs(t, verifier.Verify(goctx.Background(), m), ErrInvalidSignature)
+	}
+
+	confirmation := &Message{
+		Type:             MessageTypeSubscriptionConfirmation,
+		MessageID:        "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+		Token:            "2336412f37f",
+		TopicArn:         "arn:aws:sns:ap-northeast-1:123456789012:orders",
+		Message:          "You have chosen to subscribe to the topic",
+		SubscribeURL:     "https://sns.ap-northeast-1.amazonaws.com/?Action=ConfirmSubscription",
+		Timestamp:        "2024-05-01T09:30:15.123Z",
+		SignatureVersion: "1",
+	}
+	signer.sign(t, confirmation)
+	assert.NoError(t, verifier.Verify(goctx.Background(), confirmation))
+
+	confirmation.SignatureVersion = "3"
+	assert.ErrorIs(t, verifier.Verify(goctx.Background(), confirmation), ErrUnsupportedSignatureVersion)
+}
+
+func TestHTTPCertificateSourceRejectsForeignURL(t *testing.T) {
+	src := NewHTTPCertificateSource(nil)
+	for _, u := range []string{
+		"http://sns.ap-northeast-1.amazonaws.com/cert.pem",
+		"https://sns.ap-northeast-1.amazonaws.com.evil.com/cert.pem",
+		"https://example.com/cert.pem",
+		"https://sns.ap-northeast-1.amazonaws.com/cert.txt",
+	} {
+		_, err := src.Certificate(goctx.Background(), u)
+		assert.ErrorIs(t, err, ErrInvalidCertURL, u)
+	}
+}
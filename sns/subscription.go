@@ -0,0 +1,475 @@
+package sns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+)
+
+// FilterPolicyScope defines which part of a message a filter policy is applied to
+type FilterPolicyScope string
+
+const (
+	// FilterPolicyScopeMessageAttributes filter on message attributes (SNS default)
+	FilterPolicyScopeMessageAttributes FilterPolicyScope = "MessageAttributes"
+	// FilterPolicyScopeMessageBody filter on the JSON message payload
+	FilterPolicyScopeMessageBody FilterPolicyScope = "MessageBody"
+)
+
+// subscription attribute names used by Get/SetSubscriptionAttributes
+const (
+	subscriptionAttrRawMessageDelivery = "RawMessageDelivery"
+	subscriptionAttrFilterPolicy       = "FilterPolicy"
+	subscriptionAttrFilterPolicyScope  = "FilterPolicyScope"
+	subscriptionAttrRedrivePolicy      = "RedrivePolicy"
+	subscriptionAttrDeliveryPolicy     = "DeliveryPolicy"
+	subscriptionAttrRoleArn            = "SubscriptionRoleArn"
+)
+
+// FilterPolicy subscription filter policy builder
+//
+// With FilterPolicyScopeMessageAttributes keys are message attribute names.
+// With FilterPolicyScopeMessageBody keys are dot separated paths into the JSON
+// payload, e.g. "order.status" matches {"order": {"status": ...}}
+type FilterPolicy struct {
+	scope  FilterPolicyScope
+	policy map[string]interface{}
+	err    error
+}
+
+// NewFilterPolicy filter policy initializer, an empty scope defaults to message attributes
+func NewFilterPolicy(scope FilterPolicyScope) *FilterPolicy {
+	if scope == "" {
+		scope = FilterPolicyScopeMessageAttributes
+	}
+
+	return &FilterPolicy{
+		scope:  scope,
+		policy: map[string]interface{}{},
+	}
+}
+
+// ParseFilterPolicy parses a JSON filter policy as returned by GetSubscriptionAttributes
+func ParseFilterPolicy(scope FilterPolicyScope, policy string) (*FilterPolicy, error) {
+	p := NewFilterPolicy(scope)
+	if err := json.Unmarshal([]byte(policy), &p.policy); err != nil {
+		return nil, err
+	}
+
+	if p.policy == nil {
+		return nil, fmt.Errorf("sns: filter policy must be a JSON object")
+	}
+
+	return p, nil
+}
+
+// Scope get filter policy scope
+func (p *FilterPolicy) Scope() FilterPolicyScope {
+	return p.scope
+}
+
+// Equals matches when the value equals one of the given strings, numbers or booleans
+func (p *FilterPolicy) Equals(key string, values ...interface{}) *FilterPolicy {
+	return p.add(key, values...)
+}
+
+// AnythingBut matches when the value equals none of the given values
+func (p *FilterPolicy) AnythingBut(key string, values ...interface{}) *FilterPolicy {
+	return p.add(key, map[string]interface{}{"anything-but": values})
+}
+
+// Prefix matches when the string value starts with prefix
+func (p *FilterPolicy) Prefix(key, prefix string) *FilterPolicy {
+	return p.add(key, map[string]interface{}{"prefix": prefix})
+}
+
+// Suffix matches when the string value ends with suffix
+func (p *FilterPolicy) Suffix(key, suffix string) *FilterPolicy {
+	return p.add(key, map[string]interface{}{"suffix": suffix})
+}
+
+// EqualsIgnoreCase matches the string value case-insensitively
+func (p *FilterPolicy) EqualsIgnoreCase(key, value string) *FilterPolicy {
+	return p.add(key, map[string]interface{}{"equals-ignore-case": value})
+}
+
+// Numeric matches numeric conditions, e.g. Numeric("price", ">=", 100, "<", 200)
+func (p *FilterPolicy) Numeric(key string, conditions ...interface{}) *FilterPolicy {
+	return p.add(key, map[string]interface{}{"numeric": conditions})
+}
+
+// Exists matches on the presence (or absence) of the key
+func (p *FilterPolicy) Exists(key string, exists bool) *FilterPolicy {
+	return p.add(key, map[string]interface{}{"exists": exists})
+}
+
+// Err first conflicting rule added to the policy, if any, the conflicting rule itself is not added
+func (p *FilterPolicy) Err() error {
+	return p.err
+}
+
+// String encodes the filter policy as JSON
+func (p *FilterPolicy) String() string {
+	b, _ := json.Marshal(p.policy)
+	return string(b)
+}
+
+// add appends rules to the key, nesting the key path for payload based policies.
+// A key path running through rules, or rules added to a nested key, are recorded in Err and not added
+func (p *FilterPolicy) add(key string, rules ...interface{}) *FilterPolicy {
+	if p.err != nil {
+		return p
+	}
+
+	path := []string{key}
+	if p.scope == FilterPolicyScopeMessageBody {
+		path = strings.Split(key, ".")
+	}
+
+	// every intermediate node is checked before anything is created, so a conflict leaves the policy untouched
+	node := p.policy
+	depth := 0
+	for _, k := range path[:len(path)-1] {
+		v, ok := node[k]
+		if !ok {
+			break
+		}
+
+		child, ok := v.(map[string]interface{})
+		if !ok {
+			p.err = fmt.Errorf("sns: filter policy key %q already holds rules", strings.Join(path[:depth+1], "."))
+			return p
+		}
+		node = child
+		depth++
+	}
+
+	leaf := path[len(path)-1]
+	if depth == len(path)-1 {
+		if v, ok := node[leaf]; ok {
+			if _, ok := v.([]interface{}); !ok {
+				p.err = fmt.Errorf("sns: filter policy key %q already holds nested keys", key)
+				return p
+			}
+		}
+	}
+
+	for _, k := range path[depth : len(path)-1] {
+		child := map[string]interface{}{}
+		node[k] = child
+		node = child
+	}
+
+	existing, _ := node[leaf].([]interface{})
+	node[leaf] = append(existing, rules...)
+	return p
+}
+
+// RedrivePolicy subscription dead-letter queue settings
+type RedrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+}
+
+// SubscriptionAttributes typed subscription attributes
+type SubscriptionAttributes struct {
+	SubscriptionArn              string
+	TopicArn                     string
+	Owner                        string
+	Protocol                     string
+	Endpoint                     string
+	ConfirmationWasAuthenticated bool
+	PendingConfirmation          bool
+	RawMessageDelivery           bool
+	FilterPolicy                 *FilterPolicy
+	RedrivePolicy                *RedrivePolicy
+	DeliveryPolicy               string
+	SubscriptionRoleArn          string
+	// Raw all attributes as returned by SNS
+	Raw map[string]string
+}
+
+// ListSubscribersIterator iterates subscribers of a topic page by page
+type ListSubscribersIterator struct {
+	service   *Service
+	opts      *ListSubscribersOptions
+	page      []*sns.Subscription
+	current   *sns.Subscription
+	nextToken *string
+	started   bool
+	err       error
+}
+
+// GetSubscriptionAttributesOptions options to get subscription attributes
+type GetSubscriptionAttributesOptions struct {
+	SubscriptionArn string
+	Timeout         time.Duration
+}
+
+// GetSubscriptionAttributesResponse response for getting subscription attributes
+type GetSubscriptionAttributesResponse struct {
+	Attributes *SubscriptionAttributes
+	Error      error
+}
+
+// SetSubscriptionAttributesOptions options to set subscription attributes, nil fields are left untouched
+type SetSubscriptionAttributesOptions struct {
+	SubscriptionArn    string
+	RawMessageDelivery *bool
+	FilterPolicy       *FilterPolicy
+	// RemoveFilterPolicy clears the filter policy by setting it to {}, FilterPolicy is ignored when set
+	RemoveFilterPolicy  bool
+	RedrivePolicy       *RedrivePolicy
+	DeliveryPolicy      *string
+	SubscriptionRoleArn *string
+	Timeout             time.Duration
+}
+
+// SetSubscriptionAttributesResponse response for setting subscription attributes
+type SetSubscriptionAttributesResponse struct {
+	Error error
+}
+
+// ConfirmSubscriptionOptions options to confirm a pending subscription
+type ConfirmSubscriptionOptions struct {
+	TopicArn string
+	Token    string
+	// AuthenticateOnUnsubscribe only allow authenticated unsubscribe requests
+	AuthenticateOnUnsubscribe bool
+	Timeout                   time.Duration
+}
+
+// ConfirmSubscriptionResponse response for confirming a subscription
+type ConfirmSubscriptionResponse struct {
+	SubscriptionArn string
+	Error           error
+}
+
+// ListSubscribersIterator returns an iterator walking all subscribers of the topic
+func (s *Service) ListSubscribersIterator(opts *ListSubscribersOptions) *ListSubscribersIterator {
+	return &ListSubscribersIterator{
+		service: s,
+		opts:    opts,
+	}
+}
+
+// Next advances to the next subscriber, fetching the next page when needed
+func (it *ListSubscribersIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.nextToken == nil) {
+			it.current = nil
+			return false
+		}
+
+		it.fetch()
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Subscriber current subscriber
+func (it *ListSubscribersIterator) Subscriber() *sns.Subscription {
+	return it.current
+}
+
+// Err error occurred while fetching pages, if any
+func (it *ListSubscribersIterator) Err() error {
+	return it.err
+}
+
+// fetch loads the next page of subscribers
+func (it *ListSubscribersIterator) fetch() {
+	client := it.service.client()
+	t := 30 * time.Second
+	if it.opts.Timeout > 0 {
+		t = it.opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.ListSubscriptionsByTopicWithContext(ctx, &sns.ListSubscriptionsByTopicInput{
+		TopicArn:  aws.String(it.opts.TopicArn),
+		NextToken: it.nextToken,
+	})
+
+	it.started = true
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = output.Subscriptions
+	it.nextToken = output.NextToken
+	if aws.StringValue(it.nextToken) == "" {
+		it.nextToken = nil
+	}
+}
+
+// GetSubscriptionAttributes gets typed attributes of a subscription
+func (s *Service) GetSubscriptionAttributes(opts *GetSubscriptionAttributesOptions) (resp *GetSubscriptionAttributesResponse) {
+	resp = new(GetSubscriptionAttributesResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.GetSubscriptionAttributesWithContext(ctx, &sns.GetSubscriptionAttributesInput{
+		SubscriptionArn: aws.String(opts.SubscriptionArn),
+	})
+
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	raw := aws.StringValueMap(output.Attributes)
+	attrs := &SubscriptionAttributes{
+		SubscriptionArn:              raw["SubscriptionArn"],
+		TopicArn:                     raw["TopicArn"],
+		Owner:                        raw["Owner"],
+		Protocol:                     raw["Protocol"],
+		Endpoint:                     raw["Endpoint"],
+		ConfirmationWasAuthenticated: raw["ConfirmationWasAuthenticated"] == "true",
+		PendingConfirmation:          raw["PendingConfirmation"] == "true",
+		RawMessageDelivery:           raw[subscriptionAttrRawMessageDelivery] == "true",
+		DeliveryPolicy:               raw[subscriptionAttrDeliveryPolicy],
+		SubscriptionRoleArn:          raw[subscriptionAttrRoleArn],
+		Raw:                          raw,
+	}
+
+	if policy := raw[subscriptionAttrFilterPolicy]; policy != "" {
+		attrs.FilterPolicy, err = ParseFilterPolicy(FilterPolicyScope(raw[subscriptionAttrFilterPolicyScope]), policy)
+		if err != nil {
+			resp.Error = fmt.Errorf("invalid filter policy: %w", err)
+			return
+		}
+	}
+
+	if redrive := raw[subscriptionAttrRedrivePolicy]; redrive != "" {
+		attrs.RedrivePolicy = new(RedrivePolicy)
+		if err = json.Unmarshal([]byte(redrive), attrs.RedrivePolicy); err != nil {
+			resp.Error = fmt.Errorf("invalid redrive policy: %w", err)
+			return
+		}
+	}
+
+	resp.Attributes = attrs
+	return
+}
+
+// SetSubscriptionAttributes sets the given subscription attributes one by one, as SNS only accepts one per call
+func (s *Service) SetSubscriptionAttributes(opts *SetSubscriptionAttributesOptions) (resp *SetSubscriptionAttributesResponse) {
+	resp = new(SetSubscriptionAttributesResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	attrs, err := subscriptionAttributeUpdates(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	for _, attr := range attrs {
+		_, err := client.SetSubscriptionAttributesWithContext(ctx, &sns.SetSubscriptionAttributesInput{
+			SubscriptionArn: aws.String(opts.SubscriptionArn),
+			AttributeName:   aws.String(attr[0]),
+			AttributeValue:  aws.String(attr[1]),
+		})
+
+		if err != nil {
+			resp.Error = fmt.Errorf("failed to set %s: %w", attr[0], err)
+			return
+		}
+	}
+
+	return
+}
+
+// subscriptionAttributeUpdates attribute name and value pairs of the non nil options, in the order they must be set
+func subscriptionAttributeUpdates(opts *SetSubscriptionAttributesOptions) ([][2]string, error) {
+	// scope must be set before the policy, nested payload policies are rejected under the attributes scope
+	var attrs [][2]string
+	if opts.RawMessageDelivery != nil {
+		attrs = append(attrs, [2]string{subscriptionAttrRawMessageDelivery, strconv.FormatBool(*opts.RawMessageDelivery)})
+	}
+
+	if opts.RemoveFilterPolicy {
+		// SNS removes the filter policy when set to an empty JSON object
+		attrs = append(attrs, [2]string{subscriptionAttrFilterPolicy, "{}"})
+	} else if opts.FilterPolicy != nil {
+		if err := opts.FilterPolicy.Err(); err != nil {
+			return nil, err
+		}
+
+		attrs = append(attrs,
+			[2]string{subscriptionAttrFilterPolicyScope, string(opts.FilterPolicy.Scope())},
+			[2]string{subscriptionAttrFilterPolicy, opts.FilterPolicy.String()},
+		)
+	}
+
+	if opts.RedrivePolicy != nil {
+		redrive, err := json.Marshal(opts.RedrivePolicy)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, [2]string{subscriptionAttrRedrivePolicy, string(redrive)})
+	}
+
+	if opts.DeliveryPolicy != nil {
+		attrs = append(attrs, [2]string{subscriptionAttrDeliveryPolicy, *opts.DeliveryPolicy})
+	}
+
+	if opts.SubscriptionRoleArn != nil {
+		attrs = append(attrs, [2]string{subscriptionAttrRoleArn, *opts.SubscriptionRoleArn})
+	}
+
+	return attrs, nil
+}
+
+// ConfirmSubscription confirms a subscription with the token of a SubscriptionConfirmation message
+func (s *Service) ConfirmSubscription(opts *ConfirmSubscriptionOptions) (resp *ConfirmSubscriptionResponse) {
+	resp = new(ConfirmSubscriptionResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	input := &sns.ConfirmSubscriptionInput{
+		TopicArn: aws.String(opts.TopicArn),
+		Token:    aws.String(opts.Token),
+	}
+
+	if opts.AuthenticateOnUnsubscribe {
+		input.AuthenticateOnUnsubscribe = aws.String("true")
+	}
+
+	output, err := client.ConfirmSubscriptionWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.SubscriptionArn = aws.StringValue(output.SubscriptionArn)
+	return
+}
@@ -0,0 +1,105 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterPolicyAttributes(t *testing.T) {
+	policy := NewFilterPolicy("").
+		Equals("event.type", "order_created", "order_updated").
+		AnythingBut("store", "test").
+		Numeric("price", ">=", 100, "<", 200).
+		Exists("user_id", true)
+
+	assert.Equal(t, FilterPolicyScopeMessageAttributes, policy.Scope())
+	assert.JSONEq(t, `{
+		"event.type": ["order_created", "order_updated"],
+		"store": [{"anything-but": ["test"]}],
+		"price": [{"numeric": [">=", 100, "<", 200]}],
+		"user_id": [{"exists": true}]
+	}`, policy.String())
+}
+
+func TestFilterPolicyMessageBody(t *testing.T) {
+	policy := NewFilterPolicy(FilterPolicyScopeMessageBody).
+		Equals("order.status", "paid").
+		Prefix("order.region", "ap-").
+		Equals("order.status", "shipped")
+
+	assert.JSONEq(t, `{
+		"order": {
+			"status": ["paid", "shipped"],
+			"region": [{"prefix": "ap-"}]
+		}
+	}`, policy.String())
+}
+
+func TestParseFilterPolicy(t *testing.T) {
+	policy, err := ParseFilterPolicy(FilterPolicyScopeMessageBody, `{"order":{"status":["paid"]}}`)
+	assert.NoError(t, err)
+
+	policy.Equals("order.status", "refunded")
+	assert.JSONEq(t, `{"order":{"status":["paid","refunded"]}}`, policy.String())
+
+	_, err = ParseFilterPolicy(FilterPolicyScopeMessageBody, `not json`)
+	assert.Error(t, err)
+
+	_, err = ParseFilterPolicy(FilterPolicyScopeMessageBody, `null`)
+	assert.Error(t, err)
+
+	_, err = ParseFilterPolicy(FilterPolicyScopeMessageBody, `["paid"]`)
+	assert.Error(t, err)
+}
+
+func TestFilterPolicyConflict(t *testing.T) {
+	policy := NewFilterPolicy(FilterPolicyScopeMessageBody).
+		Equals("order", "paid").
+		Equals("order.status", "shipped")
+
+	assert.Error(t, policy.Err())
+	assert.JSONEq(t, `{"order":["paid"]}`, policy.String())
+
+	policy = NewFilterPolicy(FilterPolicyScopeMessageBody).
+		Equals("order.status", "paid").
+		Equals("order", "shipped")
+
+	assert.Error(t, policy.Err())
+	assert.JSONEq(t, `{"order":{"status":["paid"]}}`, policy.String())
+
+	policy = NewFilterPolicy(FilterPolicyScopeMessageBody).
+		Equals("order.status", "paid").
+		Equals("order.region.code", "ap")
+
+	assert.NoError(t, policy.Err())
+	assert.JSONEq(t, `{"order":{"status":["paid"],"region":{"code":["ap"]}}}`, policy.String())
+}
+
+func TestSubscriptionAttributeUpdates(t *testing.T) {
+	attrs, err := subscriptionAttributeUpdates(&SetSubscriptionAttributesOptions{
+		RawMessageDelivery: aws.Bool(true),
+		FilterPolicy:       NewFilterPolicy(FilterPolicyScopeMessageBody).Equals("order.status", "paid"),
+		RedrivePolicy:      &RedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:ap-northeast-1:123456789012:dlq"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{
+		{subscriptionAttrRawMessageDelivery, "true"},
+		{subscriptionAttrFilterPolicyScope, string(FilterPolicyScopeMessageBody)},
+		{subscriptionAttrFilterPolicy, `{"order":{"status":["paid"]}}`},
+		{subscriptionAttrRedrivePolicy, `{"deadLetterTargetArn":"arn:aws:sqs:ap-northeast-1:123456789012:dlq"}`},
+	}, attrs)
+
+	attrs, err = subscriptionAttributeUpdates(&SetSubscriptionAttributesOptions{
+		RemoveFilterPolicy: true,
+		FilterPolicy:       NewFilterPolicy(""),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{subscriptionAttrFilterPolicy, "{}"}}, attrs)
+
+	_, err = subscriptionAttributeUpdates(&SetSubscriptionAttributesOptions{
+		FilterPolicy: NewFilterPolicy(FilterPolicyScopeMessageBody).Equals("order", "paid").Equals("order.status", "paid"),
+	})
+	assert.Error(t, err)
+}
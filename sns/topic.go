@@ -0,0 +1,555 @@
+package sns
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	goctx "context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+)
+
+// fifoTopicSuffix FIFO topic names must end with this suffix
+const fifoTopicSuffix = ".fifo"
+
+// ErrContentBasedDeduplicationRequiresFIFO content based deduplication was requested for a standard topic
+var ErrContentBasedDeduplicationRequiresFIFO = errors.New("sns: content based deduplication requires a FIFO topic")
+
+// topic attribute names used by Create/Get/SetTopicAttributes
+const (
+	topicAttrDisplayName               = "DisplayName"
+	topicAttrPolicy                    = "Policy"
+	topicAttrDeliveryPolicy            = "DeliveryPolicy"
+	topicAttrKmsMasterKeyID            = "KmsMasterKeyId"
+	topicAttrFifoTopic                 = "FifoTopic"
+	topicAttrContentBasedDeduplication = "ContentBasedDeduplication"
+)
+
+// CreateTopicOptions options to create a topic
+type CreateTopicOptions struct {
+	// Name topic name, ".fifo" is appended for FIFO topics when missing
+	Name string
+	FIFO bool
+	// ContentBasedDeduplication requires FIFO
+	ContentBasedDeduplication bool
+	DisplayName               string
+	Policy                    string
+	DeliveryPolicy            string
+	KmsMasterKeyID            string
+	Tags                      map[string]string
+	Timeout                   time.Duration
+}
+
+// CreateTopicResponse response for creating a topic
+type CreateTopicResponse struct {
+	TopicArn string
+	Error    error
+}
+
+// DeleteTopicOptions options to delete a topic, TopicArn defaults to the service topic arn
+type DeleteTopicOptions struct {
+	TopicArn string
+	Timeout  time.Duration
+}
+
+// DeleteTopicResponse response for deleting a topic
+type DeleteTopicResponse struct {
+	Error error
+}
+
+// ListTopicsOptions options to list topics
+type ListTopicsOptions struct {
+	Timeout time.Duration
+}
+
+// ListTopicsResponse response for listing topics
+type ListTopicsResponse struct {
+	TopicArns []string
+	Error     error
+}
+
+// ListTopicsIterator iterates topics page by page
+type ListTopicsIterator struct {
+	service   *Service
+	opts      *ListTopicsOptions
+	page      []*sns.Topic
+	current   string
+	nextToken *string
+	started   bool
+	err       error
+}
+
+// TopicAttributes typed topic attributes
+type TopicAttributes struct {
+	TopicArn                  string
+	Owner                     string
+	DisplayName               string
+	Policy                    string
+	DeliveryPolicy            string
+	EffectiveDeliveryPolicy   string
+	KmsMasterKeyID            string
+	FIFO                      bool
+	ContentBasedDeduplication bool
+	SubscriptionsConfirmed    int
+	SubscriptionsPending      int
+	SubscriptionsDeleted      int
+	// Raw all attributes as returned by SNS
+	Raw map[string]string
+}
+
+// GetTopicAttributesOptions options to get topic attributes, TopicArn defaults to the service topic arn
+type GetTopicAttributesOptions struct {
+	TopicArn string
+	Timeout  time.Duration
+}
+
+// GetTopicAttributesResponse response for getting topic attributes
+type GetTopicAttributesResponse struct {
+	Attributes *TopicAttributes
+	Error      error
+}
+
+// SetTopicAttributesOptions options to set topic attributes, nil fields are left untouched
+type SetTopicAttributesOptions struct {
+	TopicArn       string
+	DisplayName    *string
+	Policy         *string
+	DeliveryPolicy *string
+	KmsMasterKeyID *string
+	// ContentBasedDeduplication requires a FIFO topic
+	ContentBasedDeduplication *bool
+	Timeout                   time.Duration
+}
+
+// SetTopicAttributesResponse response for setting topic attributes
+type SetTopicAttributesResponse struct {
+	Error error
+}
+
+// TagTopicOptions options to tag a topic
+type TagTopicOptions struct {
+	TopicArn string
+	Tags     map[string]string
+	Timeout  time.Duration
+}
+
+// TagTopicResponse response for tagging a topic
+type TagTopicResponse struct {
+	Error error
+}
+
+// UntagTopicOptions options to remove tags from a topic
+type UntagTopicOptions struct {
+	TopicArn string
+	TagKeys  []string
+	Timeout  time.Duration
+}
+
+// UntagTopicResponse response for removing tags from a topic
+type UntagTopicResponse struct {
+	Error error
+}
+
+// ListTopicTagsOptions options to list tags of a topic
+type ListTopicTagsOptions struct {
+	TopicArn string
+	Timeout  time.Duration
+}
+
+// ListTopicTagsResponse response for listing tags of a topic
+type ListTopicTagsResponse struct {
+	Tags  map[string]string
+	Error error
+}
+
+// CreateTopic creates a topic, creating an existing topic with the same attributes returns its arn
+func (s *Service) CreateTopic(opts *CreateTopicOptions) (resp *CreateTopicResponse) {
+	resp = new(CreateTopicResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	input, err := createTopicInput(opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	output, err := client.CreateTopicWithContext(ctx, input)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.TopicArn = aws.StringValue(output.TopicArn)
+	return
+}
+
+// DeleteTopic deletes a topic and all its subscriptions
+func (s *Service) DeleteTopic(opts *DeleteTopicOptions) (resp *DeleteTopicResponse) {
+	resp = new(DeleteTopicResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.DeleteTopicWithContext(ctx, &sns.DeleteTopicInput{
+		TopicArn: aws.String(s.topicArnOr(opts.TopicArn)),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// ListTopics lists all topics in the region
+func (s *Service) ListTopics(opts *ListTopicsOptions) (resp *ListTopicsResponse) {
+	resp = new(ListTopicsResponse)
+
+	it := s.ListTopicsIterator(opts)
+	for it.Next() {
+		resp.TopicArns = append(resp.TopicArns, it.TopicArn())
+	}
+
+	resp.Error = it.Err()
+	return
+}
+
+// ListTopicsIterator returns an iterator walking all topics in the region
+func (s *Service) ListTopicsIterator(opts *ListTopicsOptions) *ListTopicsIterator {
+	return &ListTopicsIterator{
+		service: s,
+		opts:    opts,
+	}
+}
+
+// Next advances to the next topic, fetching the next page when needed
+func (it *ListTopicsIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.nextToken == nil) {
+			it.current = ""
+			return false
+		}
+
+		it.fetch()
+	}
+
+	it.current, it.page = aws.StringValue(it.page[0].TopicArn), it.page[1:]
+	return true
+}
+
+// TopicArn current topic arn
+func (it *ListTopicsIterator) TopicArn() string {
+	return it.current
+}
+
+// Err error occurred while fetching pages, if any
+func (it *ListTopicsIterator) Err() error {
+	return it.err
+}
+
+// fetch loads the next page of topics
+func (it *ListTopicsIterator) fetch() {
+	client := it.service.client()
+	t := 30 * time.Second
+	if it.opts.Timeout > 0 {
+		t = it.opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.ListTopicsWithContext(ctx, &sns.ListTopicsInput{
+		NextToken: it.nextToken,
+	})
+
+	it.started = true
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = output.Topics
+	it.nextToken = output.NextToken
+	if aws.StringValue(it.nextToken) == "" {
+		it.nextToken = nil
+	}
+}
+
+// GetTopicAttributes gets typed attributes of a topic
+func (s *Service) GetTopicAttributes(opts *GetTopicAttributesOptions) (resp *GetTopicAttributesResponse) {
+	resp = new(GetTopicAttributesResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.GetTopicAttributesWithContext(ctx, &sns.GetTopicAttributesInput{
+		TopicArn: aws.String(s.topicArnOr(opts.TopicArn)),
+	})
+
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	resp.Attributes = toTopicAttributes(aws.StringValueMap(output.Attributes))
+	return
+}
+
+// SetTopicAttributes sets the given topic attributes one by one, as SNS only accepts one per call
+func (s *Service) SetTopicAttributes(opts *SetTopicAttributesOptions) (resp *SetTopicAttributesResponse) {
+	resp = new(SetTopicAttributesResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	topicArn := s.topicArnOr(opts.TopicArn)
+	attrs, err := topicAttributeUpdates(topicArn, opts)
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	for _, attr := range attrs {
+		_, err := client.SetTopicAttributesWithContext(ctx, &sns.SetTopicAttributesInput{
+			TopicArn:       aws.String(topicArn),
+			AttributeName:  aws.String(attr[0]),
+			AttributeValue: aws.String(attr[1]),
+		})
+
+		if err != nil {
+			resp.Error = fmt.Errorf("failed to set %s: %w", attr[0], err)
+			return
+		}
+	}
+
+	return
+}
+
+// TagTopic adds or overwrites tags of a topic
+func (s *Service) TagTopic(opts *TagTopicOptions) (resp *TagTopicResponse) {
+	resp = new(TagTopicResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.TagResourceWithContext(ctx, &sns.TagResourceInput{
+		ResourceArn: aws.String(s.topicArnOr(opts.TopicArn)),
+		Tags:        toTags(opts.Tags),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// UntagTopic removes tags from a topic
+func (s *Service) UntagTopic(opts *UntagTopicOptions) (resp *UntagTopicResponse) {
+	resp = new(UntagTopicResponse)
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	_, err := client.UntagResourceWithContext(ctx, &sns.UntagResourceInput{
+		ResourceArn: aws.String(s.topicArnOr(opts.TopicArn)),
+		TagKeys:     aws.StringSlice(opts.TagKeys),
+	})
+
+	if err != nil {
+		resp.Error = err
+	}
+
+	return
+}
+
+// ListTopicTags lists tags of a topic
+func (s *Service) ListTopicTags(opts *ListTopicTagsOptions) (resp *ListTopicTagsResponse) {
+	resp = &ListTopicTagsResponse{
+		Tags: map[string]string{},
+	}
+
+	client := s.client()
+	t := 30 * time.Second
+	if opts.Timeout > 0 {
+		t = opts.Timeout
+	}
+	ctx, cancel := goctx.WithTimeout(goctx.Background(), t)
+	defer cancel()
+
+	output, err := client.ListTagsForResourceWithContext(ctx, &sns.ListTagsForResourceInput{
+		ResourceArn: aws.String(s.topicArnOr(opts.TopicArn)),
+	})
+
+	if err != nil {
+		resp.Error = err
+		return
+	}
+
+	for _, tag := range output.Tags {
+		resp.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return
+}
+
+// createTopicInput create topic input of the options, suffixing FIFO topic names
+func createTopicInput(opts *CreateTopicOptions) (*sns.CreateTopicInput, error) {
+	if opts.ContentBasedDeduplication && !opts.FIFO {
+		return nil, ErrContentBasedDeduplicationRequiresFIFO
+	}
+
+	name := opts.Name
+	attrs := map[string]string{}
+	if opts.FIFO {
+		if !strings.HasSuffix(name, fifoTopicSuffix) {
+			name += fifoTopicSuffix
+		}
+		attrs[topicAttrFifoTopic] = "true"
+		if opts.ContentBasedDeduplication {
+			attrs[topicAttrContentBasedDeduplication] = "true"
+		}
+	}
+
+	if opts.DisplayName != "" {
+		attrs[topicAttrDisplayName] = opts.DisplayName
+	}
+
+	if opts.Policy != "" {
+		attrs[topicAttrPolicy] = opts.Policy
+	}
+
+	if opts.DeliveryPolicy != "" {
+		attrs[topicAttrDeliveryPolicy] = opts.DeliveryPolicy
+	}
+
+	if opts.KmsMasterKeyID != "" {
+		attrs[topicAttrKmsMasterKeyID] = opts.KmsMasterKeyID
+	}
+
+	input := &sns.CreateTopicInput{
+		Name: aws.String(name),
+		Tags: toTags(opts.Tags),
+	}
+
+	if len(attrs) > 0 {
+		input.Attributes = aws.StringMap(attrs)
+	}
+
+	return input, nil
+}
+
+// toTopicAttributes typed topic attributes of the raw attributes returned by SNS
+func toTopicAttributes(raw map[string]string) *TopicAttributes {
+	confirmed, _ := strconv.Atoi(raw["SubscriptionsConfirmed"])
+	pending, _ := strconv.Atoi(raw["SubscriptionsPending"])
+	deleted, _ := strconv.Atoi(raw["SubscriptionsDeleted"])
+
+	return &TopicAttributes{
+		TopicArn:                  raw["TopicArn"],
+		Owner:                     raw["Owner"],
+		DisplayName:               raw[topicAttrDisplayName],
+		Policy:                    raw[topicAttrPolicy],
+		DeliveryPolicy:            raw[topicAttrDeliveryPolicy],
+		EffectiveDeliveryPolicy:   raw["EffectiveDeliveryPolicy"],
+		KmsMasterKeyID:            raw[topicAttrKmsMasterKeyID],
+		FIFO:                      raw[topicAttrFifoTopic] == "true",
+		ContentBasedDeduplication: raw[topicAttrContentBasedDeduplication] == "true",
+		SubscriptionsConfirmed:    confirmed,
+		SubscriptionsPending:      pending,
+		SubscriptionsDeleted:      deleted,
+		Raw:                       raw,
+	}
+}
+
+// topicAttributeUpdates attribute name and value pairs of the non nil options, in a stable order.
+// Content based deduplication only exists on FIFO topics, whose arn ends with the ".fifo" name suffix
+func topicAttributeUpdates(topicArn string, opts *SetTopicAttributesOptions) ([][2]string, error) {
+	if opts.ContentBasedDeduplication != nil && !strings.HasSuffix(topicArn, fifoTopicSuffix) {
+		return nil, ErrContentBasedDeduplicationRequiresFIFO
+	}
+
+	var attrs [][2]string
+	if opts.DisplayName != nil {
+		attrs = append(attrs, [2]string{topicAttrDisplayName, *opts.DisplayName})
+	}
+
+	if opts.Policy != nil {
+		attrs = append(attrs, [2]string{topicAttrPolicy, *opts.Policy})
+	}
+
+	if opts.DeliveryPolicy != nil {
+		attrs = append(attrs, [2]string{topicAttrDeliveryPolicy, *opts.DeliveryPolicy})
+	}
+
+	if opts.KmsMasterKeyID != nil {
+		attrs = append(attrs, [2]string{topicAttrKmsMasterKeyID, *opts.KmsMasterKeyID})
+	}
+
+	if opts.ContentBasedDeduplication != nil {
+		attrs = append(attrs, [2]string{topicAttrContentBasedDeduplication, strconv.FormatBool(*opts.ContentBasedDeduplication)})
+	}
+
+	return attrs, nil
+}
+
+// topicArnOr returns arn, or the service topic arn when arn is empty
+func (s *Service) topicArnOr(arn string) string {
+	if arn != "" {
+		return arn
+	}
+
+	return s.GetTopicArn()
+}
+
+// toTags converts a tag map to SNS tags
+func toTags(tags map[string]string) []*sns.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	result := make([]*sns.Tag, 0, len(tags))
+	for k, v := range tags {
+		result = append(result, &sns.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	return result
+}
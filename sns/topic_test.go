@@ -0,0 +1,103 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTopicInput(t *testing.T) {
+	input, err := createTopicInput(&CreateTopicOptions{
+		Name:                      "orders",
+		FIFO:                      true,
+		ContentBasedDeduplication: true,
+		DisplayName:               "Orders",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "orders.fifo", aws.StringValue(input.Name))
+	assert.Equal(t, map[string]string{
+		topicAttrFifoTopic:                 "true",
+		topicAttrContentBasedDeduplication: "true",
+		topicAttrDisplayName:               "Orders",
+	}, aws.StringValueMap(input.Attributes))
+
+	// an existing suffix is kept as is
+	input, err = createTopicInput(&CreateTopicOptions{Name: "orders.fifo", FIFO: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "orders.fifo", aws.StringValue(input.Name))
+
+	input, err = createTopicInput(&CreateTopicOptions{Name: "orders"})
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", aws.StringValue(input.Name))
+	assert.Nil(t, input.Attributes)
+	assert.Nil(t, input.Tags)
+
+	_, err = createTopicInput(&CreateTopicOptions{Name: "orders", ContentBasedDeduplication: true})
+	assert.ErrorIs(t, err, ErrContentBasedDeduplicationRequiresFIFO)
+}
+
+func TestToTags(t *testing.T) {
+	assert.Nil(t, toTags(nil))
+
+	tags := toTags(map[string]string{"env": "prod"})
+	assert.Len(t, tags, 1)
+	assert.Equal(t, "env", aws.StringValue(tags[0].Key))
+	assert.Equal(t, "prod", aws.StringValue(tags[0].Value))
+}
+
+func TestTopicArnOr(t *testing.T) {
+	svc := NewService("", "")
+	svc.SetTopicArn("arn:aws:sns:ap-northeast-1:123456789012:default")
+
+	assert.Equal(t, "arn:aws:sns:ap-northeast-1:123456789012:default", svc.topicArnOr(""))
+	assert.Equal(t, "arn:aws:sns:ap-northeast-1:123456789012:orders", svc.topicArnOr("arn:aws:sns:ap-northeast-1:123456789012:orders"))
+}
+
+func TestToTopicAttributes(t *testing.T) {
+	raw := map[string]string{
+		"TopicArn":                  "arn:aws:sns:ap-northeast-1:123456789012:orders.fifo",
+		"Owner":                     "123456789012",
+		"DisplayName":               "Orders",
+		"FifoTopic":                 "true",
+		"ContentBasedDeduplication": "false",
+		"SubscriptionsConfirmed":    "3",
+		"SubscriptionsPending":      "1",
+		"SubscriptionsDeleted":      "not a number",
+	}
+
+	attrs := toTopicAttributes(raw)
+	assert.Equal(t, "arn:aws:sns:ap-northeast-1:123456789012:orders.fifo", attrs.TopicArn)
+	assert.Equal(t, "123456789012", attrs.Owner)
+	assert.Equal(t, "Orders", attrs.DisplayName)
+	assert.True(t, attrs.FIFO)
+	assert.False(t, attrs.ContentBasedDeduplication)
+	assert.Equal(t, 3, attrs.SubscriptionsConfirmed)
+	assert.Equal(t, 1, attrs.SubscriptionsPending)
+	assert.Equal(t, 0, attrs.SubscriptionsDeleted)
+	assert.Equal(t, raw, attrs.Raw)
+}
+
+func TestTopicAttributeUpdates(t *testing.T) {
+	fifoArn := "arn:aws:sns:ap-northeast-1:123456789012:orders.fifo"
+	attrs, err := topicAttributeUpdates(fifoArn, new(SetTopicAttributesOptions))
+	assert.NoError(t, err)
+	assert.Empty(t, attrs)
+
+	attrs, err = topicAttributeUpdates(fifoArn, &SetTopicAttributesOptions{
+		DisplayName:               aws.String("Orders"),
+		KmsMasterKeyID:            aws.String("alias/aws/sns"),
+		ContentBasedDeduplication: aws.Bool(false),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{
+		{topicAttrDisplayName, "Orders"},
+		{topicAttrKmsMasterKeyID, "alias/aws/sns"},
+		{topicAttrContentBasedDeduplication, "false"},
+	}, attrs)
+
+	_, err = topicAttributeUpdates("arn:aws:sns:ap-northeast-1:123456789012:orders", &SetTopicAttributesOptions{
+		ContentBasedDeduplication: aws.Bool(true),
+	})
+	assert.ErrorIs(t, err, ErrContentBasedDeduplicationRequiresFIFO)
+}